@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// BatchOption is an option of ForEach and Map.
+type BatchOption func(o *batchOptions)
+
+type batchOptions struct {
+	maxErrors       int
+	stopOnPermanent bool
+	key             func(index int) interface{}
+}
+
+// StopAfter stops processing of items after n items have failed.
+func StopAfter(n int) BatchOption {
+	return func(o *batchOptions) {
+		o.maxErrors = n
+	}
+}
+
+// StopOnPermanent stops processing of items after an item has failed
+// with an error that is not Transient.
+func StopOnPermanent() BatchOption {
+	return func(o *batchOptions) {
+		o.stopOnPermanent = true
+	}
+}
+
+// KeyBy sets a function returning a key of the item with the given index
+// to be used in the resulting KeyedList. By default items are keyed by their index.
+func KeyBy(key func(index int) interface{}) BatchOption {
+	return func(o *batchOptions) {
+		o.key = key
+	}
+}
+
+// ForEach calls fn for every item in a new goroutine with at most workers
+// goroutines active at once. If workers is not positive, the number of
+// active goroutines is not limited.
+//
+// The context passed to fn is canceled when ctx is done, when processing is stopped
+// by one of the options or when ForEach returns.
+// Items are not processed after the context is canceled.
+//
+// ForEach returns a KeyedList of errors of the failed items keyed by their index,
+// or nil if all items were processed successfully. Panics in fn are recovered and
+// reported as errors created like Recover does. If items were not processed because
+// ctx is done, the context error is reported for the first unprocessed item.
+func ForEach[T any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, index int, item T) error, opts ...BatchOption) error {
+	_, err := Map(ctx, items, workers, func(ctx context.Context, index int, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, index, item)
+	}, opts...)
+	return err
+}
+
+// Map calls fn for every item and collects the results like ForEach does.
+//
+// Results are ordered like the items. Results of failed items are whatever fn
+// returned along with the error, results of unprocessed items are zero values.
+func Map[T, R any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, index int, item T) (R, error), opts ...BatchOption) ([]R, error) {
+	var o batchOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	g := &Group{}
+	gctx := g.withContext(ctx, nil)
+	if workers > 0 {
+		g.SetLimit(workers)
+	}
+
+	results := make([]R, len(items))
+	var failed int32
+	skipped := -1
+	for i := range items {
+		g.acquire()
+		if gctx.Err() != nil {
+			g.release()
+			skipped = i
+			break
+		}
+		g.start(func(index int) error {
+			err := Catch(func() (err error) {
+				results[index], err = fn(gctx, index, items[index])
+				return err
+			})
+			if err != nil {
+				n := atomic.AddInt32(&failed, 1)
+				if (o.maxErrors > 0 && int(n) >= o.maxErrors) || (o.stopOnPermanent && !Is(err, Transient)) {
+					g.cancel(err)
+				}
+			}
+			return err
+		}, nil)
+	}
+	_ = g.Wait()
+
+	var list KeyedList
+	for _, task := range g.Failed() {
+		list.Add(o.keyOf(task.Index), task.Err)
+	}
+	if skipped >= 0 && ctx.Err() != nil {
+		list.Add(o.keyOf(skipped), context.Cause(ctx))
+	}
+	return results, list.ErrOrNil()
+}
+
+func (o *batchOptions) keyOf(index int) interface{} {
+	if o.key == nil {
+		return index
+	}
+	return o.key(index)
+}
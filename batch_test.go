@@ -0,0 +1,138 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_ForEach(t *testing.T) {
+	t.Run("no items", func(t *testing.T) {
+		err := errors.ForEach(context.Background(), []int{}, 2, func(ctx context.Context, i int, item int) error {
+			return fmt.Errorf("unexpected call")
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var sum int32
+		err := errors.ForEach(context.Background(), []int32{1, 2, 3, 4}, 2, func(ctx context.Context, i int, item int32) error {
+			atomic.AddInt32(&sum, item)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(10), sum)
+	})
+
+	t.Run("failed items", func(t *testing.T) {
+		items := []string{"a", "", "c", "", "e"}
+		err := errors.ForEach(context.Background(), items, 0, func(ctx context.Context, i int, item string) error {
+			if item == "" {
+				return errors.E("empty item", errors.Invalid)
+			}
+			return nil
+		})
+		list, ok := err.(errors.KeyedList)
+		assert.True(t, ok)
+		assert.Len(t, list, 2)
+		assert.Equal(t, 1, list[0].Key)
+		assert.Equal(t, 3, list[1].Key)
+		assert.True(t, errors.Is(list.Get(1), errors.Invalid))
+		assert.Nil(t, list.Get(0))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		err := errors.ForEach(context.Background(), []int{0, 1}, 1, func(ctx context.Context, i int, item int) error {
+			if item == 1 {
+				panic("boom")
+			}
+			return nil
+		})
+		list := err.(errors.KeyedList)
+		assert.Len(t, list, 1)
+		assert.Equal(t, 1, list[0].Key)
+		assert.Equal(t, "panic: boom", list[0].Err.Error())
+	})
+
+	t.Run("key by", func(t *testing.T) {
+		ids := []string{"id1", "id2", "id3"}
+		err := errors.ForEach(context.Background(), ids, 2, func(ctx context.Context, i int, id string) error {
+			if id == "id2" {
+				return errors.E(errors.NotFound)
+			}
+			return nil
+		}, errors.KeyBy(func(i int) interface{} { return ids[i] }))
+		list := err.(errors.KeyedList)
+		assert.Len(t, list, 1)
+		assert.Equal(t, "id2", list[0].Key)
+	})
+
+	t.Run("stop after", func(t *testing.T) {
+		var calls int32
+		err := errors.ForEach(context.Background(), make([]int, 100), 1, func(ctx context.Context, i int, item int) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.E("failed", errors.Transient)
+		}, errors.StopAfter(3))
+		assert.Len(t, err.(errors.KeyedList), 3)
+		assert.Equal(t, int32(3), calls)
+	})
+
+	t.Run("stop on permanent", func(t *testing.T) {
+		var calls int32
+		err := errors.ForEach(context.Background(), make([]int, 100), 1, func(ctx context.Context, i int, item int) error {
+			atomic.AddInt32(&calls, 1)
+			if i < 2 {
+				return errors.E("transient", errors.Transient)
+			}
+			return errors.E("permanent", errors.Invalid)
+		}, errors.StopOnPermanent())
+		list := err.(errors.KeyedList)
+		assert.Len(t, list, 3)
+		assert.True(t, errors.Is(list.Get(2), errors.Invalid))
+		assert.Equal(t, int32(3), calls)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := errors.ForEach(ctx, make([]int, 10), 1, func(ctx context.Context, i int, item int) error {
+			if i == 2 {
+				cancel()
+			}
+			return nil
+		})
+		list := err.(errors.KeyedList)
+		assert.Len(t, list, 1)
+		assert.Equal(t, 3, list[0].Key)
+		assert.ErrorIs(t, list[0].Err, context.Canceled)
+	})
+}
+
+func Test_Map(t *testing.T) {
+	t.Run("results", func(t *testing.T) {
+		res, err := errors.Map(context.Background(), []int{1, 2, 3, 4, 5}, 2, func(ctx context.Context, i int, item int) (string, error) {
+			time.Sleep(time.Duration(5-item) * time.Millisecond)
+			return fmt.Sprint(item * 10), nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"10", "20", "30", "40", "50"}, res)
+	})
+
+	t.Run("partial results", func(t *testing.T) {
+		res, err := errors.Map(context.Background(), []int{1, 0, 2}, 0, func(ctx context.Context, i int, item int) (int, error) {
+			if item == 0 {
+				return -1, errors.E("division by zero", errors.Invalid)
+			}
+			return 10 / item, nil
+		})
+		assert.Equal(t, []int{10, -1, 5}, res)
+		list := err.(errors.KeyedList)
+		assert.Len(t, list, 1)
+		assert.Equal(t, 1, list[0].Key)
+		assert.Equal(t, "1: division by zero", list.Error())
+	})
+}
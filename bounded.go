@@ -0,0 +1,220 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BoundedList is an error type that accumulates errors while retaining
+// at most a fixed number of them. It could be used instead of a List
+// when the number of errors is unbounded, like in a long running batch job.
+//
+// BoundedList retains the first errors added to it and, optionally,
+// the last ones. Errors in between are dropped, but their number is
+// counted for each ErrorCode.
+//
+// BoundedList is not safe for concurrent use.
+type BoundedList struct {
+	maxFirst  int
+	maxLast   int
+	first     List
+	last      List // ring buffer of the last errors
+	lastStart int
+	total     int
+	dropped   map[ErrorCode]int
+}
+
+// NewBoundedList creates a list that retains the first n and the last m errors.
+func NewBoundedList(n, m int) *BoundedList {
+	if n < 0 || m < 0 {
+		panic(fmt.Sprintf("bad call to NewBoundedList: negative capacity %d, %d", n, m))
+	}
+	return &BoundedList{maxFirst: n, maxLast: m}
+}
+
+// Add adds an error to list. If list is full, an error is dropped,
+// either the given one or the oldest of the last retained errors.
+func (l *BoundedList) Add(e error) {
+	if e == nil {
+		return
+	}
+	l.total++
+	if len(l.first) < l.maxFirst {
+		l.first = append(l.first, e)
+		return
+	}
+	if l.maxLast == 0 {
+		l.drop(e)
+		return
+	}
+	if len(l.last) < l.maxLast {
+		l.last = append(l.last, e)
+		return
+	}
+	l.drop(l.last[l.lastStart])
+	l.last[l.lastStart] = e
+	l.lastStart = (l.lastStart + 1) % len(l.last)
+}
+
+func (l *BoundedList) drop(e error) {
+	if l.dropped == nil {
+		l.dropped = make(map[ErrorCode]int)
+	}
+	l.dropped[Code(e)]++
+}
+
+// Len returns the number of errors added to list, including dropped ones.
+func (l *BoundedList) Len() int {
+	return l.total
+}
+
+// Dropped returns the number of dropped errors.
+func (l *BoundedList) Dropped() int {
+	return l.total - len(l.first) - len(l.last)
+}
+
+// DroppedCodes returns the number of dropped errors for each ErrorCode.
+func (l *BoundedList) DroppedCodes() map[ErrorCode]int {
+	res := make(map[ErrorCode]int, len(l.dropped))
+	for code, n := range l.dropped {
+		res[code] = n
+	}
+	return res
+}
+
+// Errors returns retained errors in the order they were added.
+func (l *BoundedList) Errors() List {
+	res := make(List, 0, len(l.first)+len(l.last))
+	res = append(res, l.first...)
+	res = append(res, l.last[l.lastStart:]...)
+	res = append(res, l.last[:l.lastStart]...)
+	return res
+}
+
+// ErrOrNil return nil if no errors were added to list, otherwise list itself.
+func (l *BoundedList) ErrOrNil() error {
+	if l.total == 0 {
+		return nil
+	}
+	return l
+}
+
+// Unwrap returns retained errors of the list.
+// It allows errors.Is and errors.As from the standard library to examine every retained error.
+func (l *BoundedList) Unwrap() []error {
+	return l.Errors()
+}
+
+// Error returns human readable representation of retained errors
+// followed by the number of dropped errors for each ErrorCode:
+//
+//	48215 errors occurred:
+//		* error one
+//		* error two
+//		... and 48213 more errors (NotFound: 48000, Invalid: 213)
+func (l *BoundedList) Error() string {
+	if l.Dropped() == 0 {
+		return DefaultListFormatter(l.Errors())
+	}
+	sb := &strings.Builder{}
+	sb.WriteString(errorsOccurred(l.total))
+	sb.WriteString(":")
+	errs := l.Errors()
+	for i := range errs {
+		if i == len(l.first) {
+			l.writeDropped(sb)
+		}
+		sb.WriteString("\n\t* ")
+		sb.WriteString(strings.ReplaceAll(errs[i].Error(), "\n", "\n\t"))
+	}
+	if len(l.last) == 0 {
+		l.writeDropped(sb)
+	}
+	return sb.String()
+}
+
+func (l *BoundedList) writeDropped(sb *strings.Builder) {
+	sb.WriteString("\n\t... and ")
+	sb.WriteString(strconv.Itoa(l.Dropped()))
+	sb.WriteString(" more errors")
+	if len(l.dropped) == 0 {
+		return
+	}
+	codes := make([]ErrorCode, 0, len(l.dropped))
+	for code := range l.dropped {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		ni, nj := l.dropped[codes[i]], l.dropped[codes[j]]
+		if ni != nj {
+			return ni > nj
+		}
+		return codes[i] < codes[j]
+	})
+	sb.WriteString(" (")
+	for i, code := range codes {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(code.String())
+		sb.WriteString(": ")
+		sb.WriteString(strconv.Itoa(l.dropped[code]))
+	}
+	sb.WriteString(")")
+}
+
+// boundedListJSON is a JSON representation of a BoundedList.
+type boundedListJSON struct {
+	First        List           `json:"first"`
+	Last         List           `json:"last,omitempty"`
+	Dropped      int            `json:"dropped,omitempty"`
+	DroppedCodes map[string]int `json:"dropped_codes,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// Retained errors are encoded as arrays along with the dropped errors counts.
+func (l *BoundedList) MarshalJSON() ([]byte, error) {
+	errs := l.Errors()
+	v := boundedListJSON{
+		First:   errs[:len(l.first)],
+		Last:    errs[len(l.first):],
+		Dropped: l.Dropped(),
+	}
+	if len(l.dropped) > 0 {
+		v.DroppedCodes = make(map[string]int, len(l.dropped))
+		for code, n := range l.dropped {
+			v.DroppedCodes[code.String()] = n
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Capacities of the decoded list are the numbers of its retained errors.
+// Dropped errors with code names unknown to the program are counted as Unexpected.
+func (l *BoundedList) UnmarshalJSON(data []byte) error {
+	var v boundedListJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var dropped map[ErrorCode]int
+	if len(v.DroppedCodes) > 0 {
+		dropped = make(map[ErrorCode]int, len(v.DroppedCodes))
+		for name, n := range v.DroppedCodes {
+			code, _ := parseCode(name)
+			dropped[code] += n
+		}
+	}
+	*l = BoundedList{
+		maxFirst: len(v.First),
+		maxLast:  len(v.Last),
+		first:    v.First,
+		last:     v.Last,
+		total:    len(v.First) + len(v.Last) + v.Dropped,
+		dropped:  dropped,
+	}
+	return nil
+}
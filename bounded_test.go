@@ -0,0 +1,135 @@
+package errors_test
+
+import (
+	"encoding/json"
+	stderr "errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_BoundedList(t *testing.T) {
+	newList := func(n, m int) *errors.BoundedList {
+		list := errors.NewBoundedList(n, m)
+		list.Add(errors.E("err1", errors.NotFound))
+		list.Add(nil)
+		list.Add(errors.E("err2", errors.Invalid))
+		list.Add(errors.E("err3", errors.NotFound))
+		list.Add(fmt.Errorf("err4"))
+		list.Add(errors.E("err5", errors.NotFound))
+		return list
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		list := errors.NewBoundedList(2, 1)
+		assert.Nil(t, list.ErrOrNil())
+		assert.Equal(t, 0, list.Len())
+		assert.Equal(t, errors.List{}, list.Errors())
+		assert.Equal(t, "", list.Error())
+	})
+
+	t.Run("not full", func(t *testing.T) {
+		list := newList(3, 3)
+		assert.Equal(t, 5, list.Len())
+		assert.Equal(t, 0, list.Dropped())
+		assert.Len(t, list.Errors(), 5)
+		assert.Equal(t, "5 errors occurred:\n\t* err1\n\t* err2\n\t* err3\n\t* err4\n\t* err5", list.Error())
+	})
+
+	t.Run("first", func(t *testing.T) {
+		list := newList(1, 0)
+		assert.Equal(t, 5, list.Len())
+		assert.Equal(t, 4, list.Dropped())
+		assert.Equal(t, map[errors.ErrorCode]int{errors.NotFound: 2, errors.Invalid: 1, errors.Unexpected: 1}, list.DroppedCodes())
+		assert.Equal(t, "5 errors occurred:\n\t* err1\n\t... and 4 more errors (NotFound: 2, Unexpected: 1, Invalid: 1)", list.Error())
+		assert.Equal(t, list, list.ErrOrNil())
+	})
+
+	t.Run("first and last", func(t *testing.T) {
+		list := newList(1, 2)
+		assert.Equal(t, 2, list.Dropped())
+		assert.Equal(t, map[errors.ErrorCode]int{errors.Invalid: 1, errors.NotFound: 1}, list.DroppedCodes())
+		msgs := []string{}
+		for _, err := range list.Errors() {
+			msgs = append(msgs, err.Error())
+		}
+		assert.Equal(t, []string{"err1", "err4", "err5"}, msgs)
+		assert.Equal(t, "5 errors occurred:\n\t* err1\n\t... and 2 more errors (Invalid: 1, NotFound: 1)\n\t* err4\n\t* err5", list.Error())
+	})
+
+	t.Run("last only", func(t *testing.T) {
+		list := newList(0, 1)
+		assert.Equal(t, "5 errors occurred:\n\t... and 4 more errors (NotFound: 2, Unexpected: 1, Invalid: 1)\n\t* err5", list.Error())
+	})
+
+	t.Run("many errors", func(t *testing.T) {
+		list := errors.NewBoundedList(2, 0)
+		for i := 0; i < 48002; i++ {
+			list.Add(errors.E("not found", errors.NotFound))
+		}
+		for i := 0; i < 213; i++ {
+			list.Add(errors.E("invalid", errors.Invalid))
+		}
+		assert.Len(t, list.Errors(), 2)
+		assert.Contains(t, list.Error(), "... and 48213 more errors (NotFound: 48000, Invalid: 213)")
+	})
+
+	t.Run("is and as", func(t *testing.T) {
+		list := errors.NewBoundedList(1, 1)
+		list.Add(fs.ErrClosed)
+		list.Add(errors.E("dropped", errors.Invalid))
+		list.Add(errors.E("last", errors.NotFound, errors.Client))
+
+		var err error = list
+		assert.True(t, errors.Is(err, fs.ErrClosed))
+		assert.True(t, errors.Is(err, errors.NotFound))
+		assert.False(t, errors.Is(err, errors.Invalid))
+		assert.True(t, errors.Has(err, errors.Client))
+		assert.Len(t, errors.Multiple(err), 2)
+		assert.True(t, stderr.Is(err, fs.ErrClosed))
+
+		var res *errors.Error
+		assert.True(t, errors.As(err, &res))
+		assert.Equal(t, "last", res.Msg)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		list := newList(1, 1)
+		b1, err := json.Marshal(list)
+		assert.NoError(t, err)
+
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b1, &obj))
+		assert.Equal(t, float64(3), obj["dropped"])
+		assert.Equal(t, map[string]interface{}{"NotFound": float64(1), "Invalid": float64(1), "Unexpected": float64(1)}, obj["dropped_codes"])
+		assert.Len(t, obj["first"], 1)
+		assert.Len(t, obj["last"], 1)
+
+		res := &errors.BoundedList{}
+		assert.NoError(t, json.Unmarshal(b1, res))
+		assert.Equal(t, list.Len(), res.Len())
+		assert.Equal(t, list.Error(), res.Error())
+		assert.True(t, errors.Is(res, errors.NotFound))
+
+		b2, err := json.Marshal(res)
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(b1), string(b2))
+	})
+
+	t.Run("json unknown codes", func(t *testing.T) {
+		data := `{"first":[],"dropped":6,"dropped_codes":{"SomeAppCode":2,"NotFound":1,"Unexpected":3}}`
+		res := &errors.BoundedList{}
+		assert.NoError(t, json.Unmarshal([]byte(data), res))
+		assert.Equal(t, 6, res.Dropped())
+		assert.Equal(t, map[errors.ErrorCode]int{errors.NotFound: 1, errors.Unexpected: 5}, res.DroppedCodes())
+	})
+
+	t.Run("bad capacity", func(t *testing.T) {
+		assert.PanicsWithValue(t, "bad call to NewBoundedList: negative capacity -1, 0", func() {
+			errors.NewBoundedList(-1, 0)
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"context"
+	stderr "errors"
+	"io"
+	"io/fs"
+	"sync"
+)
+
+// Classifier determines a kind and a code of a foreign error, that is an error
+// which is not an *Error. It returns false if it does not recognize err.
+type Classifier func(err error) (kind ErrorKind, code ErrorCode, ok bool)
+
+var classifierRegistry = struct {
+	sync.RWMutex
+	classifiers []Classifier
+}{}
+
+func init() {
+	RegisterSentinel(fs.ErrNotExist, 0, NotFound)
+	RegisterSentinel(fs.ErrPermission, 0, Permission)
+	RegisterSentinel(fs.ErrExist, 0, AlreadyExists)
+	RegisterSentinel(io.ErrUnexpectedEOF, 0, IO)
+	RegisterClassifier(classifyTimeout)
+}
+
+// RegisterClassifier registers a classifier consulted by Kind, Code and Is
+// for foreign errors. Classifiers are consulted in reverse order of registration
+// until one of them recognizes an error, so classifiers registered by an
+// application take precedence over the built-in ones.
+//
+// The built-in classifiers recognize fs.ErrNotExist, fs.ErrPermission,
+// fs.ErrExist, io.ErrUnexpectedEOF, timeouts, including context.DeadlineExceeded
+// and net.Error timeouts, connection resets and EAGAIN on platforms that have them,
+// as well as database errors, see SQLStateError and SQLNumberError.
+//
+// Classifiers are called without holding locks, so they may call Kind, Code
+// and Is themselves, or register other classifiers.
+func RegisterClassifier(c Classifier) {
+	if c == nil {
+		panic("bad call to RegisterClassifier: nil classifier")
+	}
+	classifierRegistry.Lock()
+	defer classifierRegistry.Unlock()
+	classifierRegistry.classifiers = append(classifierRegistry.classifiers, c)
+}
+
+// RegisterSentinel registers a classifier that recognizes errors matching target,
+// as reported by errors.Is from the standard library, as errors of the given kind and code.
+func RegisterSentinel(target error, kind ErrorKind, code ErrorCode) {
+	RegisterClassifier(func(err error) (ErrorKind, ErrorCode, bool) {
+		return kind, code, stderr.Is(err, target)
+	})
+}
+
+// classify determines a kind and a code of a foreign error with registered classifiers.
+func classify(err error) (ErrorKind, ErrorCode, bool) {
+	if err == nil {
+		return 0, 0, false
+	}
+	// Registered classifiers are only appended, so the slice could be used
+	// after the lock is released.
+	classifierRegistry.RLock()
+	classifiers := classifierRegistry.classifiers
+	classifierRegistry.RUnlock()
+	for i := len(classifiers) - 1; i >= 0; i-- {
+		if kind, code, ok := classifiers[i](err); ok {
+			return kind, code, true
+		}
+	}
+	return 0, 0, false
+}
+
+// classifyTimeout recognizes timeouts as Transient errors.
+func classifyTimeout(err error) (ErrorKind, ErrorCode, bool) {
+	if stderr.Is(err, context.DeadlineExceeded) {
+		return Transient, 0, true
+	}
+	var timeout interface{ Timeout() bool }
+	if stderr.As(err, &timeout) && timeout.Timeout() {
+		return Transient, 0, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,12 @@
+//go:build !plan9
+
+package errors
+
+import (
+	"syscall"
+)
+
+func init() {
+	RegisterSentinel(syscall.ECONNRESET, Transient, IO)
+	RegisterSentinel(syscall.EAGAIN, Transient, 0)
+}
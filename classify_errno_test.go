@@ -0,0 +1,27 @@
+//go:build !plan9
+
+package errors_test
+
+import (
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_ClassifyErrno(t *testing.T) {
+	t.Run("connection reset", func(t *testing.T) {
+		err := &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+		assert.Equal(t, errors.Transient, errors.Kind(err))
+		assert.Equal(t, errors.IO, errors.Code(err))
+		assert.True(t, errors.IsRetryable(errors.E(errors.Op("op"), err)))
+	})
+
+	t.Run("again", func(t *testing.T) {
+		assert.Equal(t, errors.Transient, errors.Kind(syscall.EAGAIN))
+		assert.Equal(t, errors.Unexpected, errors.Code(syscall.EAGAIN))
+	})
+}
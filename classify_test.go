@@ -0,0 +1,120 @@
+package errors_test
+
+import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+var errQuota = stderr.New("quota exceeded")
+
+type rateLimitError struct{}
+
+func (rateLimitError) Error() string { return "rate limited" }
+
+func init() {
+	errors.RegisterSentinel(errQuota, errors.Client, codeQuotaExceeded)
+	errors.RegisterClassifier(func(err error) (errors.ErrorKind, errors.ErrorCode, bool) {
+		var e rateLimitError
+		return errors.Client | errors.Transient, 0, stderr.As(err, &e)
+	})
+}
+
+func Test_Classify(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		kind errors.ErrorKind
+		code errors.ErrorCode
+	}{
+		{"unclassified", fmt.Errorf("some error"), 0, errors.Unexpected},
+		{"not exist", &fs.PathError{Op: "open", Path: "file", Err: fs.ErrNotExist}, 0, errors.NotFound},
+		{"os not exist", os.ErrNotExist, 0, errors.NotFound},
+		{"permission", fmt.Errorf("wrapped: %w", fs.ErrPermission), 0, errors.Permission},
+		{"exist", fs.ErrExist, 0, errors.AlreadyExists},
+		{"invalid", fs.ErrInvalid, 0, errors.Unexpected},
+		{"unexpected eof", io.ErrUnexpectedEOF, 0, errors.IO},
+		{"eof", io.EOF, 0, errors.Unexpected},
+		{"deadline exceeded", context.DeadlineExceeded, errors.Transient, errors.Unexpected},
+		{"canceled", context.Canceled, 0, errors.Unexpected},
+		{"os deadline exceeded", os.ErrDeadlineExceeded, errors.Transient, errors.Unexpected},
+		{"net timeout", &net.OpError{Op: "dial", Err: &net.DNSError{IsTimeout: true}}, errors.Transient, errors.Unexpected},
+		{"net error", &net.OpError{Op: "dial", Err: &net.DNSError{IsNotFound: true}}, 0, errors.Unexpected},
+		{"custom sentinel", fmt.Errorf("wrapped: %w", errQuota), errors.Client, codeQuotaExceeded},
+		{"custom classifier", rateLimitError{}, errors.Client | errors.Transient, errors.Unexpected},
+		{"http", http.ErrHandlerTimeout, 0, errors.Unexpected},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.kind, errors.Kind(tc.err))
+			assert.Equal(t, tc.code, errors.Code(tc.err))
+			assert.True(t, errors.Is(tc.err, tc.code))
+			if tc.kind != 0 {
+				assert.True(t, errors.Is(tc.err, tc.kind))
+			}
+			assert.False(t, errors.Is(tc.err, errors.Server))
+
+			wrapped := errors.E(errors.Op("op"), "wrapped", tc.err)
+			assert.Equal(t, tc.kind, errors.Kind(wrapped))
+			assert.Equal(t, tc.code, errors.Code(wrapped))
+			assert.True(t, errors.Is(wrapped, tc.code))
+		})
+	}
+
+	t.Run("error takes precedence", func(t *testing.T) {
+		err := errors.E(errors.Server, errors.IO, fs.ErrNotExist)
+		assert.Equal(t, errors.Server, errors.Kind(err))
+		assert.Equal(t, errors.IO, errors.Code(err))
+		assert.False(t, errors.Is(err, errors.NotFound))
+	})
+
+	t.Run("lists", func(t *testing.T) {
+		list := errors.List{fmt.Errorf("some error"), fs.ErrNotExist}
+		assert.True(t, errors.Has(list, errors.NotFound))
+		assert.True(t, errors.Is(list, errors.NotFound))
+		assert.True(t, errors.Is(stderr.Join(io.EOF, context.DeadlineExceeded), errors.Transient))
+	})
+
+	t.Run("http status", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, errors.HTTPStatus(os.ErrNotExist))
+	})
+
+	t.Run("reentrant classifier", func(t *testing.T) {
+		errReentrant := stderr.New("reentrant")
+		errors.RegisterClassifier(func(err error) (errors.ErrorKind, errors.ErrorCode, bool) {
+			if !stderr.Is(err, errReentrant) {
+				return 0, 0, false
+			}
+			errors.RegisterClassifier(func(error) (errors.ErrorKind, errors.ErrorCode, bool) {
+				return 0, 0, false
+			})
+			return errors.Kind(io.ErrUnexpectedEOF), errors.Code(io.ErrUnexpectedEOF), true
+		})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			assert.Equal(t, errors.IO, errors.Code(errReentrant))
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("classifier deadlocked")
+		}
+	})
+
+	t.Run("nil classifier", func(t *testing.T) {
+		assert.PanicsWithValue(t, "bad call to RegisterClassifier: nil classifier", func() {
+			errors.RegisterClassifier(nil)
+		})
+	})
+}
@@ -1,5 +1,13 @@
 package errors
 
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
 // ErrorKind is an error's kind
 //
 // Error can have multiple kinds specified at once.
@@ -28,3 +36,266 @@ const (
 	AlreadyExists
 	NotFound
 )
+
+// CodeInfo describes a registered error code.
+type CodeInfo struct {
+	Code        ErrorCode
+	Name        string
+	Description string
+}
+
+// CodeOption is an option of a registered error code.
+type CodeOption func(info *CodeInfo)
+
+// WithDescription sets a human readable description of the error code.
+func WithDescription(desc string) CodeOption {
+	return func(info *CodeInfo) {
+		info.Description = desc
+	}
+}
+
+var codeRegistry = struct {
+	sync.RWMutex
+	byCode map[ErrorCode]CodeInfo
+	byName map[string]ErrorCode
+}{
+	byCode: make(map[ErrorCode]CodeInfo),
+	byName: make(map[string]ErrorCode),
+}
+
+func init() {
+	RegisterCode(Unexpected, "Unexpected", WithDescription("Unexpected error"))
+	RegisterCode(Invalid, "Invalid", WithDescription("Invalid argument or input"))
+	RegisterCode(IO, "IO", WithDescription("Input/output failure"))
+	RegisterCode(Deadlock, "Deadlock", WithDescription("Deadlock or conflicting concurrent update"))
+	RegisterCode(Permission, "Permission", WithDescription("Permission denied"))
+	RegisterCode(AlreadyExists, "AlreadyExists", WithDescription("Entity already exists"))
+	RegisterCode(NotFound, "NotFound", WithDescription("Entity not found"))
+}
+
+// RegisterCode registers an error code with the given name.
+// Registered codes are represented by their names when printed or marshaled to text.
+//
+// RegisterCode panics if either the code or the name is already registered,
+// so it should be called during package initialization.
+func RegisterCode(code ErrorCode, name string, opts ...CodeOption) {
+	if name == "" {
+		panic("bad call to RegisterCode: empty name")
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		panic("bad call to RegisterCode: numeric name " + name)
+	}
+	info := CodeInfo{Code: code, Name: name}
+	for _, opt := range opts {
+		opt(&info)
+	}
+	codeRegistry.Lock()
+	defer codeRegistry.Unlock()
+	if existing, ok := codeRegistry.byCode[code]; ok {
+		panic(fmt.Sprintf("bad call to RegisterCode: code %d already registered as %s", code, existing.Name))
+	}
+	if existing, ok := codeRegistry.byName[name]; ok {
+		panic(fmt.Sprintf("bad call to RegisterCode: name %s already registered for code %d", name, existing))
+	}
+	codeRegistry.byCode[code] = info
+	codeRegistry.byName[name] = code
+}
+
+// Codes returns all registered error codes ordered by code.
+func Codes() []CodeInfo {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+	res := make([]CodeInfo, 0, len(codeRegistry.byCode))
+	for _, info := range codeRegistry.byCode {
+		res = append(res, info)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Code < res[j].Code
+	})
+	return res
+}
+
+// String returns the name of the code if it is registered, or its number otherwise.
+func (c ErrorCode) String() string {
+	codeRegistry.RLock()
+	info, ok := codeRegistry.byCode[c]
+	codeRegistry.RUnlock()
+	if ok {
+		return info.Name
+	}
+	return strconv.Itoa(int(c))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ErrorCode) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The text should be either a registered code name or a number.
+func (c *ErrorCode) UnmarshalText(text []byte) error {
+	code, ok := parseCode(string(text))
+	if !ok {
+		return E(Invalid, "unknown error code "+strconv.Quote(string(text)))
+	}
+	*c = code
+	return nil
+}
+
+// parseCode returns the code with the given name or number.
+func parseCode(s string) (ErrorCode, bool) {
+	if code, ok := codeByName(s); ok {
+		return code, true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return Unexpected, false
+	}
+	return ErrorCode(n), true
+}
+
+// codeByName returns the registered code with the given name.
+func codeByName(name string) (ErrorCode, bool) {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+	code, ok := codeRegistry.byName[name]
+	return code, ok
+}
+
+var kindRegistry = struct {
+	sync.RWMutex
+	names  map[ErrorKind]string
+	byName map[string]ErrorKind
+	used   ErrorKind
+}{
+	names:  make(map[ErrorKind]string),
+	byName: make(map[string]ErrorKind),
+}
+
+func init() {
+	registerKind(Client, "Client")
+	registerKind(Server, "Server")
+	registerKind(Transient, "Transient")
+}
+
+// maxKindBits is a number of bits available for error kinds.
+const maxKindBits = strconv.IntSize - 1
+
+// RegisterKind allocates a new error kind with the given name.
+// Allocated kinds never share bits with other registered kinds.
+//
+// RegisterKind panics if the name is already registered or if there are
+// no more bits available, so it should be called during package initialization.
+func RegisterKind(name string) ErrorKind {
+	kindRegistry.Lock()
+	defer kindRegistry.Unlock()
+	for bit := 0; bit < maxKindBits; bit++ {
+		kind := ErrorKind(1) << bit
+		if kindRegistry.used&kind == 0 {
+			registerKindLocked(kind, name)
+			return kind
+		}
+	}
+	panic("bad call to RegisterKind: no more kinds available")
+}
+
+func registerKind(kind ErrorKind, name string) {
+	kindRegistry.Lock()
+	defer kindRegistry.Unlock()
+	registerKindLocked(kind, name)
+}
+
+func registerKindLocked(kind ErrorKind, name string) {
+	if name == "" || strings.ContainsAny(name, "|") {
+		panic("bad call to RegisterKind: invalid name " + strconv.Quote(name))
+	}
+	if _, err := strconv.ParseInt(name, 0, 0); err == nil {
+		panic("bad call to RegisterKind: numeric name " + name)
+	}
+	if _, ok := kindRegistry.byName[name]; ok {
+		panic("bad call to RegisterKind: name " + name + " already registered")
+	}
+	if kindRegistry.used&kind != 0 {
+		panic(fmt.Sprintf("bad call to RegisterKind: kind %#x already registered as %s", int(kind), kindRegistry.names[kind]))
+	}
+	kindRegistry.names[kind] = name
+	kindRegistry.byName[name] = kind
+	kindRegistry.used |= kind
+}
+
+// Kinds returns all registered error kinds ordered by their bits.
+func Kinds() []ErrorKind {
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+	var res []ErrorKind
+	for bit := 0; bit < maxKindBits; bit++ {
+		kind := ErrorKind(1) << bit
+		if kindRegistry.used&kind != 0 {
+			res = append(res, kind)
+		}
+	}
+	return res
+}
+
+// String returns names of registered kinds set in k separated by "|", e.g. "Client|Transient".
+// Bits of unregistered kinds are rendered as a hexadecimal number.
+func (k ErrorKind) String() string {
+	if k == 0 {
+		return "0"
+	}
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+	var names []string
+	for bit := 0; bit < maxKindBits; bit++ {
+		kind := ErrorKind(1) << bit
+		if k&kind == 0 {
+			continue
+		}
+		if name, ok := kindRegistry.names[kind]; ok {
+			names = append(names, name)
+			k &^= kind
+		}
+	}
+	if k != 0 {
+		names = append(names, fmt.Sprintf("%#x", int(k)))
+	}
+	return strings.Join(names, "|")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k ErrorKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The text should be registered kind names or numbers separated by "|".
+func (k *ErrorKind) UnmarshalText(text []byte) error {
+	kind, unknown := parseKind(string(text))
+	if unknown != "" {
+		return E(Invalid, "unknown error kind "+strconv.Quote(unknown))
+	}
+	*k = kind
+	return nil
+}
+
+// parseKind returns the kind with the given names or numbers separated by "|".
+// Unknown names are skipped, the first of them is returned as unknown.
+func parseKind(s string) (kind ErrorKind, unknown string) {
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+	for _, name := range strings.Split(s, "|") {
+		if k, ok := kindRegistry.byName[name]; ok {
+			kind |= k
+			continue
+		}
+		n, err := strconv.ParseInt(name, 0, 0)
+		if err != nil {
+			if unknown == "" {
+				unknown = name
+			}
+			continue
+		}
+		kind |= ErrorKind(n)
+	}
+	return kind, unknown
+}
@@ -0,0 +1,157 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+const (
+	codeQuotaExceeded errors.ErrorCode = 100 + iota
+	codeUnregistered
+)
+
+func init() {
+	errors.RegisterCode(codeQuotaExceeded, "QuotaExceeded", errors.WithDescription("Quota exceeded"))
+}
+
+func Test_RegisterCode(t *testing.T) {
+	t.Run("duplicate code", func(t *testing.T) {
+		assert.PanicsWithValue(t, "bad call to RegisterCode: code 6 already registered as NotFound", func() {
+			errors.RegisterCode(errors.NotFound, "Missing")
+		})
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		assert.PanicsWithValue(t, "bad call to RegisterCode: name NotFound already registered for code 6", func() {
+			errors.RegisterCode(1000, "NotFound")
+		})
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		assert.PanicsWithValue(t, "bad call to RegisterCode: empty name", func() {
+			errors.RegisterCode(1000, "")
+		})
+		assert.PanicsWithValue(t, "bad call to RegisterCode: numeric name 42", func() {
+			errors.RegisterCode(1000, "42")
+		})
+	})
+}
+
+func Test_Codes(t *testing.T) {
+	codes := errors.Codes()
+	names := make([]string, len(codes))
+	for i := range codes {
+		names[i] = codes[i].Name
+	}
+	assert.Equal(t, []string{
+		"Unexpected", "Invalid", "IO", "Deadlock", "Permission", "AlreadyExists", "NotFound", "QuotaExceeded",
+	}, names)
+	assert.Equal(t, errors.CodeInfo{
+		Code:        codeQuotaExceeded,
+		Name:        "QuotaExceeded",
+		Description: "Quota exceeded",
+	}, codes[len(codes)-1])
+}
+
+func Test_ErrorCode_String(t *testing.T) {
+	assert.Equal(t, "Unexpected", errors.Unexpected.String())
+	assert.Equal(t, "NotFound", errors.NotFound.String())
+	assert.Equal(t, "QuotaExceeded", codeQuotaExceeded.String())
+	assert.Equal(t, "101", codeUnregistered.String())
+	assert.Equal(t, "NotFound", fmt.Sprintf("%v", errors.NotFound))
+}
+
+func Test_ErrorCode_Text(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, code := range []errors.ErrorCode{errors.Unexpected, errors.NotFound, codeQuotaExceeded, codeUnregistered} {
+			b, err := code.MarshalText()
+			assert.NoError(t, err)
+			var res errors.ErrorCode
+			assert.NoError(t, res.UnmarshalText(b))
+			assert.Equal(t, code, res)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(map[string]errors.ErrorCode{"code": errors.AlreadyExists})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"code":"AlreadyExists"}`, string(b))
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		var res errors.ErrorCode
+		err := res.UnmarshalText([]byte("Missing"))
+		assert.True(t, errors.Is(err, errors.Invalid))
+	})
+}
+
+var kindRetryable = errors.RegisterKind("Retryable")
+
+func Test_RegisterKind(t *testing.T) {
+	t.Run("allocation", func(t *testing.T) {
+		assert.Equal(t, errors.Transient<<1, kindRetryable)
+		assert.Equal(t, []errors.ErrorKind{errors.Client, errors.Server, errors.Transient, kindRetryable}, errors.Kinds())
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		assert.PanicsWithValue(t, "bad call to RegisterKind: name Client already registered", func() {
+			errors.RegisterKind("Client")
+		})
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		assert.PanicsWithValue(t, `bad call to RegisterKind: invalid name ""`, func() {
+			errors.RegisterKind("")
+		})
+		assert.PanicsWithValue(t, `bad call to RegisterKind: invalid name "A|B"`, func() {
+			errors.RegisterKind("A|B")
+		})
+		assert.PanicsWithValue(t, "bad call to RegisterKind: numeric name 0x10", func() {
+			errors.RegisterKind("0x10")
+		})
+	})
+}
+
+func Test_ErrorKind_String(t *testing.T) {
+	assert.Equal(t, "0", errors.ErrorKind(0).String())
+	assert.Equal(t, "Client", errors.Client.String())
+	assert.Equal(t, "Client|Transient", (errors.Client | errors.Transient).String())
+	assert.Equal(t, "Server|Retryable", (errors.Server | kindRetryable).String())
+	assert.Equal(t, "Client|0x400", (errors.Client | errors.ErrorKind(1<<10)).String())
+	assert.Equal(t, "Client|Server", fmt.Sprintf("%v", errors.Client|errors.Server))
+}
+
+func Test_ErrorKind_Text(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		kinds := []errors.ErrorKind{
+			0,
+			errors.Client,
+			errors.Server | errors.Transient,
+			kindRetryable,
+			errors.Client | errors.ErrorKind(1<<10),
+		}
+		for _, kind := range kinds {
+			b, err := kind.MarshalText()
+			assert.NoError(t, err)
+			var res errors.ErrorKind
+			assert.NoError(t, res.UnmarshalText(b))
+			assert.Equal(t, kind, res)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(map[string]errors.ErrorKind{"kind": errors.Client | errors.Transient})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"kind":"Client|Transient"}`, string(b))
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		var res errors.ErrorKind
+		err := res.UnmarshalText([]byte("Client|Fatal"))
+		assert.True(t, errors.Is(err, errors.Invalid))
+	})
+}
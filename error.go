@@ -12,16 +12,29 @@ type Op string
 
 // Error is an error wrapper.
 type Error struct {
-	Op    Op
-	Kind  ErrorKind
-	Code  ErrorCode
-	Msg   string
-	Cause error
-	Stack StackTrace
+	Op     Op
+	Kind   ErrorKind
+	Code   ErrorCode
+	Msg    string
+	Fields map[string]interface{}
+	Cause  error
+	Stack  StackTrace
+
+	// frames is a stack trace of an error decoded from JSON.
+	frames []Frame
+	// panicked is set for errors created from panics, whose code is
+	// Unexpected regardless of the code of the panic value.
+	panicked bool
+	// attempts are errors of all attempts of an error returned by Retry.
+	attempts List
 }
 
 // E creates or wraps an error.
-// Arguments could be an Op, ErrorKind, ErrorCode, string message, or an error to wrap.
+// Arguments could be an Op, ErrorKind, ErrorCode, string message, Field,
+// map[string]interface{} of fields, or an error to wrap.
+//
+// A stack trace is captured unless there is an *Error with a stack trace
+// anywhere in the chain of the wrapped error.
 func E(args ...interface{}) *Error {
 	e := &Error{}
 	wrapping := false
@@ -44,6 +57,12 @@ func E(args ...interface{}) *Error {
 				panic("bad call to E: multiple messages")
 			}
 			e.Msg = a
+		case Field:
+			e.setField(a.Key, a.Value)
+		case map[string]interface{}:
+			for k, v := range a {
+				e.setField(k, v)
+			}
 		case error:
 			if e.Cause != nil {
 				panic("bad call to E: multiple causes")
@@ -56,18 +75,18 @@ func E(args ...interface{}) *Error {
 			panic("bad call to E: argument of type " + reflect.TypeOf(a).String())
 		}
 	}
-	shouldTrace := true
-	if wrapping {
-		if _, ok := e.Cause.(*Error); ok {
-			shouldTrace = false
-		}
-	}
-	if shouldTrace {
+	if !wrapping || !walk(e.Cause, hasStack) {
 		e.Stack = callers()
 	}
 	return e
 }
 
+// hasStack reports whether err is an *Error with a stack trace.
+func hasStack(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.Stack != nil
+}
+
 // Unwrap returns the result of calling the Unwrap method on err, if err's
 // type contains an Unwrap method returning error.
 // Otherwise, Unwrap returns nil.
@@ -82,64 +101,99 @@ func Unwrap(err error) error {
 }
 
 // Ops returns stack of error operations.
+//
+// The whole chain of err is examined, including errors wrapped by foreign errors.
+// For lists and other multi-errors, operations of the first error that has any are returned.
 func Ops(err error) []Op {
-	e, ok := err.(*Error)
-	if !ok {
-		return []Op{}
-	}
 	var res []Op
-	if e.Op != "" {
+	if e, ok := err.(*Error); ok && e.Op != "" {
 		res = append(res, e.Op)
 	}
-	cause, ok := e.Cause.(*Error)
-	if !ok {
-		return res
+	for _, cause := range unwrapAll(err) {
+		if ops := Ops(cause); len(ops) > 0 {
+			return append(res, ops...)
+		}
 	}
-	res = append(res, Ops(cause)...)
 	return res
 }
 
 // Trace returns error's stack trace.
+//
+// The innermost stack trace in the chain of err is returned.
+// For lists and other multi-errors, the trace of the first error that has one is returned.
 func Trace(err error) StackTrace {
-	e, ok := err.(*Error)
-	if !ok {
-		return nil
+	for _, cause := range unwrapAll(err) {
+		if trace := Trace(cause); trace != nil {
+			return trace
+		}
 	}
-	cause, ok := e.Cause.(*Error)
-	if !ok {
+	if e, ok := err.(*Error); ok {
 		return e.Stack
 	}
-	return Trace(cause)
+	return nil
 }
 
-// Kind returns error's kind.
-func Kind(err error) ErrorKind {
-	e, ok := err.(*Error)
-	if !ok {
-		return 0
+// Frames returns symbolized frames of error's stack trace.
+// Unlike Trace, it also returns stack traces of errors decoded from JSON.
+//
+// Frames of the innermost stack trace in the chain of err are returned.
+// For lists and other multi-errors, frames of the first error that has them are returned.
+func Frames(err error) []Frame {
+	for _, cause := range unwrapAll(err) {
+		if frames := Frames(cause); frames != nil {
+			return frames
+		}
 	}
-	if e.Kind != 0 {
-		return e.Kind
+	if e, ok := err.(*Error); ok {
+		if e.Stack != nil {
+			return e.Stack.Frames()
+		}
+		return e.frames
 	}
-	if e.Cause != nil {
-		return Kind(e.Cause)
+	return nil
+}
+
+// Kind returns error's kind.
+//
+// The kind of the first *Error in the chain of err that has one is returned,
+// including errors wrapped by foreign errors and errors in lists.
+// Otherwise, the kind is determined by registered classifiers.
+func Kind(err error) ErrorKind {
+	var kind ErrorKind
+	walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok && e.Kind != 0 {
+			kind = e.Kind
+			return true
+		}
+		return false
+	})
+	if kind == 0 {
+		kind, _, _ = classify(err)
 	}
-	return 0
+	return kind
 }
 
 // Code returns error's code.
+//
+// The code of the first *Error in the chain of err that has one is returned,
+// including errors wrapped by foreign errors and errors in lists.
+// Otherwise, the code is determined by registered classifiers.
 func Code(err error) ErrorCode {
-	e, ok := err.(*Error)
-	if !ok {
-		return Unexpected
-	}
-	if e.Code != 0 {
-		return e.Code
+	var code ErrorCode
+	found := walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok && (e.Code != 0 || e.panicked) {
+			code = e.Code
+			return true
+		}
+		return false
+	})
+	if !found {
+		_, code, _ = classify(err)
 	}
-	if e.Cause != nil {
-		return Code(e.Cause)
+	if code == 0 {
+		return Unexpected
 	}
-	return Unexpected
+	return code
 }
 
 // Is checks if err is of given kind, has given code or matches given error what.
@@ -158,11 +212,29 @@ func Is(err error, what interface{}) bool {
 		}
 		return false
 	}
-	if _, ok := what.(ErrorKind); ok {
+	if list, ok := err.(KeyedList); ok {
+		for i := range list {
+			if Is(list[i].Err, what) {
+				return true
+			}
+		}
 		return false
 	}
+	if multi, ok := err.(multiError); ok {
+		if _, ok := what.(error); !ok {
+			for _, e := range multi.Unwrap() {
+				if Is(e, what) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	if kind, ok := what.(ErrorKind); ok {
+		return Kind(err)&kind > 0
+	}
 	if code, ok := what.(ErrorCode); ok {
-		return code == Unexpected
+		return Code(err) == code
 	}
 	if target, ok := what.(error); ok {
 		return stderr.Is(err, target)
@@ -185,14 +257,14 @@ func IsAnyOf(err error, what ...interface{}) bool {
 
 // As finds the first error in err's chain that matches target, and if so, sets
 // target to that error value and returns true. Otherwise, it returns false.
-// If err is an error list, it does so for every error in the list.
+// If err is an error list or any other multi-error, it does so for every error in it.
 func As(err error, target interface{}) bool {
 	if err == nil {
 		return false
 	}
-	if list, ok := err.(List); ok {
-		for i := range list {
-			if As(list[i], target) {
+	if multi, ok := err.(multiError); ok {
+		for _, e := range multi.Unwrap() {
+			if As(e, target) {
 				return true
 			}
 		}
@@ -201,18 +273,47 @@ func As(err error, target interface{}) bool {
 }
 
 // ClientMsg returns error message suitable to display to the client.
+//
+// The message of the first *Error of Client kind in the chain of err is returned,
+// including errors wrapped by foreign errors and errors in lists.
 func ClientMsg(err error) string {
-	e, ok := err.(*Error)
-	if !ok {
-		return ""
+	var msg string
+	walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok && e.Kind&Client > 0 {
+			msg = e.Msg
+			return true
+		}
+		return false
+	})
+	return msg
+}
+
+// unwrapAll returns errors wrapped by err, either a single one or all errors of a multi-error.
+func unwrapAll(err error) []error {
+	if multi, ok := err.(multiError); ok {
+		return multi.Unwrap()
 	}
-	if e.Kind&Client > 0 {
-		return e.Msg
+	if cause := Unwrap(err); cause != nil {
+		return []error{cause}
 	}
-	if e.Cause != nil {
-		return ClientMsg(e.Cause)
+	return nil
+}
+
+// walk calls f for err and every error in its chain in depth-first order
+// until f returns true. It reports whether f returned true.
+func walk(err error, f func(err error) bool) bool {
+	if err == nil {
+		return false
+	}
+	if f(err) {
+		return true
 	}
-	return ""
+	for _, cause := range unwrapAll(err) {
+		if walk(cause, f) {
+			return true
+		}
+	}
+	return false
 }
 
 // Error return human readable representation of an error.
@@ -247,7 +348,7 @@ func (e *Error) Is(what interface{}) bool {
 			return e.Kind&what > 0
 		}
 	case ErrorCode:
-		if e.Code != 0 {
+		if e.Code != 0 || e.panicked {
 			return e.Code == what
 		}
 	default:
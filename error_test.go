@@ -62,6 +62,21 @@ func Test_E(t *testing.T) {
 			_ = errors.E(err, "msg", errors.Invalid, err)
 		})
 	})
+
+	t.Run("stack trace", func(t *testing.T) {
+		assert.NotNil(t, errors.E("msg").Stack)
+		assert.NotNil(t, errors.E("msg", fmt.Errorf("cause")).Stack)
+		assert.Nil(t, errors.E("msg", findUser(1)).Stack)
+		assert.Nil(t, errors.E("msg", fmt.Errorf("wrapped: %w", findUser(1))).Stack)
+		assert.Nil(t, errors.E("msg", errors.List{fmt.Errorf("err1"), findUser(1)}).Stack)
+
+		stackless := &errors.Error{Msg: "stackless"}
+		assert.NotNil(t, errors.E("msg", stackless).Stack)
+		assert.NotNil(t, errors.E("msg", fmt.Errorf("wrapped: %w", stackless)).Stack)
+		wrapped := errors.E(errors.Op("op"), stackless)
+		assert.Nil(t, errors.E("msg", wrapped).Stack)
+		assert.Equal(t, wrapped.Stack, errors.Trace(errors.E("msg", wrapped)))
+	})
 }
 
 func Test_Kind(t *testing.T) {
@@ -104,6 +119,19 @@ func Test_Kind(t *testing.T) {
 		kind := errors.Kind(err2)
 		assert.Equal(t, errors.Client, kind)
 	})
+
+	t.Run("wrapped by foreign error", func(t *testing.T) {
+		err := errors.E("wrapped", fmt.Errorf("foreign: %w", findUser(2)))
+		assert.Equal(t, errors.Server|errors.Transient, errors.Kind(err))
+		assert.True(t, errors.Is(err, errors.Transient))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list := errors.List{fmt.Errorf("err1"), errors.E("err2"), findUser(1), findUser(2)}
+		assert.Equal(t, errors.Client, errors.Kind(list))
+		assert.Equal(t, errors.Client, errors.Kind(errors.E("wrapped", list)))
+		assert.Equal(t, errors.Server|errors.Transient, errors.Kind(stderr.Join(fmt.Errorf("err1"), findUser(2))))
+	})
 }
 
 func Test_Code(t *testing.T) {
@@ -135,6 +163,19 @@ func Test_Code(t *testing.T) {
 		err2 := errors.E("wrapped", errors.IO, err1)
 		assert.Equal(t, errors.IO, errors.Code(err2))
 	})
+
+	t.Run("wrapped by foreign error", func(t *testing.T) {
+		err := errors.E("wrapped", fmt.Errorf("foreign: %w", findUser(1)))
+		assert.Equal(t, errors.NotFound, errors.Code(err))
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list := errors.List{fmt.Errorf("err1"), errors.E("err2"), findUser(2), findUser(1)}
+		assert.Equal(t, errors.IO, errors.Code(list))
+		assert.Equal(t, errors.IO, errors.Code(errors.E("wrapped", list)))
+		assert.Equal(t, errors.Unexpected, errors.Code(errors.List{fmt.Errorf("err1")}))
+	})
 }
 
 func Test_Is(t *testing.T) {
@@ -341,6 +382,16 @@ func Test_Error_Message(t *testing.T) {
 		assert.Equal(t, "msg2: msg1", err2.Error())
 		assert.Equal(t, "msg1", errors.ClientMsg(err2))
 	})
+
+	t.Run("wrapped by foreign error", func(t *testing.T) {
+		err := errors.E("wrapped", fmt.Errorf("foreign: %w", findUser(1)))
+		assert.Equal(t, "user not found: 1", errors.ClientMsg(err))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list := errors.List{findUser(2), errors.E("msg2"), findUser(1)}
+		assert.Equal(t, "user not found: 1", errors.ClientMsg(errors.E("wrapped", list)))
+	})
 }
 
 func Test_Ops(t *testing.T) {
@@ -372,6 +423,19 @@ func Test_Ops(t *testing.T) {
 		err2 := errors.E(errors.Op("op2"), "msg2", err1)
 		assert.Equal(t, []errors.Op{"op2", "op1"}, errors.Ops(err2))
 	})
+
+	t.Run("wrapped by foreign error", func(t *testing.T) {
+		err1 := errors.E(errors.Op("op1"), "msg1")
+		err2 := fmt.Errorf("foreign: %w", err1)
+		err3 := errors.E(errors.Op("op3"), "msg3", err2)
+		assert.Equal(t, []errors.Op{"op3", "op1"}, errors.Ops(err3))
+		assert.Equal(t, []errors.Op{"op1"}, errors.Ops(err2))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list := errors.List{fmt.Errorf("msg1"), errors.E("msg2"), findUser(1), errors.E(errors.Op("op4"), "msg4")}
+		assert.Equal(t, []errors.Op{"op", "db.findUser"}, errors.Ops(errors.E(errors.Op("op"), list)))
+	})
 }
 
 func Test_Unwrap(t *testing.T) {
@@ -420,6 +484,27 @@ func Test_Trace(t *testing.T) {
 		assert.Contains(t, fmt.Sprintf("%+v", trace[1]), "Test_Trace")
 	})
 
+	t.Run("wrapped by foreign error", func(t *testing.T) {
+		err := errors.E("msg", fmt.Errorf("foreign: %w", findUser(1)))
+		trace := errors.Trace(err)
+		assert.NotNil(t, trace)
+		assert.Contains(t, fmt.Sprintf("%+v", trace[0]), "findUser")
+	})
+
+	t.Run("foreign error wrapped", func(t *testing.T) {
+		trace := errors.Trace(errors.E("msg", fmt.Errorf("foreign")))
+		assert.NotNil(t, trace)
+		assert.Contains(t, fmt.Sprintf("%+v", trace[0]), "Test_Trace")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		err := errors.E("msg", errors.List{fmt.Errorf("err1"), findUser(1)})
+		trace := errors.Trace(err)
+		assert.NotNil(t, trace)
+		assert.Contains(t, fmt.Sprintf("%+v", trace[0]), "findUser")
+		assert.Nil(t, errors.Trace(errors.List{fmt.Errorf("err1")}))
+	})
+
 	t.Run("formatting", func(t *testing.T) {
 		err := findUser(1)
 		trace := errors.Trace(err)
@@ -0,0 +1,46 @@
+package errors
+
+// Field is a key/value pair attached to an error to provide structured context,
+// like request or entity identifiers.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F creates a Field to be passed to E.
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Fields returns fields of the error merged from every layer of the error chain,
+// including errors wrapped by foreign errors. For lists and other multi-errors,
+// fields of the first error that has any are merged.
+// Fields of outer errors override fields of inner errors with the same key.
+// Returns nil if there are no fields.
+func Fields(err error) map[string]interface{} {
+	var res map[string]interface{}
+	for _, cause := range unwrapAll(err) {
+		if res = Fields(cause); res != nil {
+			break
+		}
+	}
+	e, ok := err.(*Error)
+	if !ok || len(e.Fields) == 0 {
+		return res
+	}
+	if res == nil {
+		res = make(map[string]interface{}, len(e.Fields))
+	}
+	for k, v := range e.Fields {
+		res[k] = v
+	}
+	return res
+}
+
+// setField adds a field to the error, overriding the existing one with the same key.
+func (e *Error) setField(key string, value interface{}) {
+	if e.Fields == nil {
+		e.Fields = make(map[string]interface{})
+	}
+	e.Fields[key] = value
+}
@@ -0,0 +1,68 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_Fields(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.Nil(t, errors.Fields(nil))
+	})
+
+	t.Run("string error", func(t *testing.T) {
+		assert.Nil(t, errors.Fields(fmt.Errorf("msg")))
+	})
+
+	t.Run("error without fields", func(t *testing.T) {
+		assert.Nil(t, errors.Fields(errors.E("msg")))
+	})
+
+	t.Run("field", func(t *testing.T) {
+		err := errors.E("msg", errors.F("user_id", 42), errors.F("request_id", "abc"))
+		assert.Equal(t, map[string]interface{}{"user_id": 42, "request_id": "abc"}, errors.Fields(err))
+	})
+
+	t.Run("map", func(t *testing.T) {
+		err := errors.E("msg", map[string]interface{}{"user_id": 42}, errors.F("request_id", "abc"))
+		assert.Equal(t, map[string]interface{}{"user_id": 42, "request_id": "abc"}, errors.Fields(err))
+	})
+
+	t.Run("same key", func(t *testing.T) {
+		err := errors.E(errors.F("key", 1), errors.F("key", 2))
+		assert.Equal(t, map[string]interface{}{"key": 2}, errors.Fields(err))
+	})
+
+	t.Run("wrapped errors", func(t *testing.T) {
+		err1 := errors.E("msg1", errors.F("user_id", 42), errors.F("entity", "inner"))
+		err2 := errors.E("msg2", err1, errors.F("request_id", "abc"), errors.F("entity", "outer"))
+		assert.Equal(t, map[string]interface{}{
+			"user_id":    42,
+			"request_id": "abc",
+			"entity":     "outer",
+		}, errors.Fields(err2))
+		assert.Equal(t, map[string]interface{}{"user_id": 42, "entity": "inner"}, errors.Fields(err1))
+	})
+
+	t.Run("wrapped string error", func(t *testing.T) {
+		err := errors.E(fmt.Errorf("msg1"), errors.F("key", "value"))
+		assert.Equal(t, map[string]interface{}{"key": "value"}, errors.Fields(err))
+	})
+
+	t.Run("wrapped by foreign error", func(t *testing.T) {
+		err1 := errors.E("msg1", errors.F("user_id", 42), errors.F("entity", "inner"))
+		err2 := fmt.Errorf("msg2: %w", err1)
+		err3 := errors.E("msg3", err2, errors.F("entity", "outer"))
+		assert.Equal(t, map[string]interface{}{"user_id": 42, "entity": "inner"}, errors.Fields(err2))
+		assert.Equal(t, map[string]interface{}{"user_id": 42, "entity": "outer"}, errors.Fields(err3))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list := errors.List{fmt.Errorf("msg1"), errors.E("msg2"), errors.E("msg3", errors.F("key", 3)), errors.E("msg4", errors.F("key", 4))}
+		assert.Equal(t, map[string]interface{}{"key": 3}, errors.Fields(list))
+		assert.Equal(t, map[string]interface{}{"key": 3, "op": "outer"}, errors.Fields(errors.E(list, errors.F("op", "outer"))))
+	})
+}
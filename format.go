@@ -0,0 +1,144 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Format formats the error according to the fmt.Formatter interface.
+//
+//	%s    error message
+//	%v    equivalent to %s
+//	%q    double-quoted error message
+//
+// Format accepts flags that alter the printing of some verbs, as follows:
+//
+//	%+v   error message followed by operations, kind, code, fields
+//	      and stack trace of the error, each on its own line
+//	%#v   Go-syntax-like representation of the error structure
+//
+//nolint:errcheck
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case s.Flag('+'):
+			e.formatVerbose(s)
+		case s.Flag('#'):
+			e.formatGoSyntax(s)
+		default:
+			io.WriteString(s, e.Error())
+		}
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+//nolint:errcheck
+func (e *Error) formatVerbose(w io.Writer) {
+	io.WriteString(w, e.Error())
+	if ops := Ops(e); len(ops) > 0 {
+		names := make([]string, len(ops))
+		for i := range ops {
+			names[i] = string(ops[i])
+		}
+		fmt.Fprintf(w, "\nops: %s", strings.Join(names, " > "))
+	}
+	if kind := Kind(e); kind != 0 {
+		fmt.Fprintf(w, "\nkind: %s", kind)
+	}
+	fmt.Fprintf(w, "\ncode: %s", Code(e))
+	if fields := Fields(e); len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		io.WriteString(w, "\nfields:")
+		for _, k := range keys {
+			fmt.Fprintf(w, " %s=%v", k, fields[k])
+		}
+	}
+	if frames := Frames(e); len(frames) > 0 {
+		io.WriteString(w, "\nstack:")
+		for _, f := range frames {
+			io.WriteString(w, "\n")
+			io.WriteString(w, f.verbose())
+		}
+	}
+}
+
+//nolint:errcheck
+func (e *Error) formatGoSyntax(w io.Writer) {
+	var parts []string
+	if e.Op != "" {
+		parts = append(parts, fmt.Sprintf("Op:%q", e.Op))
+	}
+	if e.Kind != 0 {
+		parts = append(parts, fmt.Sprintf("Kind:%s", e.Kind))
+	}
+	if e.Code != 0 {
+		parts = append(parts, fmt.Sprintf("Code:%s", e.Code))
+	}
+	if e.Msg != "" {
+		parts = append(parts, fmt.Sprintf("Msg:%q", e.Msg))
+	}
+	if len(e.Fields) > 0 {
+		parts = append(parts, fmt.Sprintf("Fields:%#v", e.Fields))
+	}
+	if e.Cause != nil {
+		parts = append(parts, fmt.Sprintf("Cause:%#v", e.Cause))
+	}
+	fmt.Fprintf(w, "&errors.Error{%s}", strings.Join(parts, ", "))
+}
+
+// Format formats the list according to the fmt.Formatter interface.
+//
+//	%s    error message of the list
+//	%v    equivalent to %s
+//	%q    double-quoted error message of the list
+//
+// Format accepts flags that alter the printing of some verbs, as follows:
+//
+//	%+v   verbose representation of every error in the list
+//	%#v   Go-syntax-like representation of the list
+func (l List) Format(s fmt.State, verb rune) {
+	l.format(s, verb, l.Error())
+}
+
+// format formats the list with the given message.
+//
+//nolint:errcheck
+func (l List) format(s fmt.State, verb rune, msg string) {
+	switch verb {
+	case 'v':
+		switch {
+		case s.Flag('+'):
+			fmt.Fprintf(s, "%d errors:", len(l))
+			for i := range l {
+				fmt.Fprintf(s, "\n[%d] %+v", i, l[i])
+			}
+		case s.Flag('#'):
+			parts := make([]string, len(l))
+			for i := range l {
+				parts[i] = fmt.Sprintf("%#v", l[i])
+			}
+			fmt.Fprintf(s, "errors.List{%s}", strings.Join(parts, ", "))
+		default:
+			io.WriteString(s, msg)
+		}
+	case 's':
+		io.WriteString(s, msg)
+	case 'q':
+		fmt.Fprintf(s, "%q", msg)
+	}
+}
+
+// Format formats the list like List.Format does, using the list's formatter for its message.
+func (l FormattedList) Format(s fmt.State, verb rune) {
+	l.List.format(s, verb, l.Error())
+}
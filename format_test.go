@@ -0,0 +1,80 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_Error_Format(t *testing.T) {
+	err1 := findUser(1)
+	err2 := errors.E(errors.Op("api.GetUser"), "can't get user", errors.F("request_id", "abc"), errors.F("attempt", 2), err1)
+
+	t.Run("%s", func(t *testing.T) {
+		assert.Equal(t, "can't get user: user not found: 1", fmt.Sprintf("%s", err2))
+	})
+
+	t.Run("%v", func(t *testing.T) {
+		assert.Equal(t, "can't get user: user not found: 1", fmt.Sprintf("%v", err2))
+	})
+
+	t.Run("%q", func(t *testing.T) {
+		assert.Equal(t, `"can't get user: user not found: 1"`, fmt.Sprintf("%q", err2))
+	})
+
+	t.Run("%+v", func(t *testing.T) {
+		s := fmt.Sprintf("%+v", err2)
+		lines := strings.Split(s, "\n")
+		assert.Equal(t, []string{
+			"can't get user: user not found: 1",
+			"ops: api.GetUser > db.findUser",
+			"kind: Client",
+			"code: NotFound",
+			"fields: attempt=2 request_id=abc",
+			"stack:",
+			"github.com/w1ck3dg0ph3r/go-errors_test.findUser",
+		}, lines[:7])
+		assert.Contains(t, lines[7], "error_test.go:")
+		assert.Contains(t, s, "github.com/w1ck3dg0ph3r/go-errors_test.Test_Error_Format")
+	})
+
+	t.Run("%+v without details", func(t *testing.T) {
+		err := &errors.Error{Msg: "msg"}
+		assert.Equal(t, "msg\ncode: Unexpected", fmt.Sprintf("%+v", err))
+	})
+
+	t.Run("%#v", func(t *testing.T) {
+		err := errors.E(errors.Op("op2"), "msg2", errors.F("id", 42),
+			errors.E(errors.Op("op1"), "msg1", errors.Client, errors.Transient, errors.NotFound))
+		assert.Equal(t,
+			`&errors.Error{Op:"op2", Msg:"msg2", Fields:map[string]interface {}{"id":42}, `+
+				`Cause:&errors.Error{Op:"op1", Kind:Client|Transient, Code:NotFound, Msg:"msg1"}}`,
+			fmt.Sprintf("%#v", err))
+	})
+}
+
+func Test_List_Format(t *testing.T) {
+	list := errors.List{errors.E(errors.Op("op"), "msg1", errors.Invalid), fmt.Errorf("msg2")}
+
+	t.Run("%s", func(t *testing.T) {
+		assert.Equal(t, list.Error(), fmt.Sprintf("%s", list))
+		assert.Equal(t, list.Error(), fmt.Sprintf("%v", list))
+		assert.Equal(t, fmt.Sprintf("%q", list.Error()), fmt.Sprintf("%q", list))
+	})
+
+	t.Run("%+v", func(t *testing.T) {
+		s := fmt.Sprintf("%+v", list)
+		assert.True(t, strings.HasPrefix(s, "2 errors:\n[0] msg1\nops: op\ncode: Invalid\nstack:"))
+		assert.True(t, strings.HasSuffix(s, "\n[1] msg2"))
+	})
+
+	t.Run("%#v", func(t *testing.T) {
+		list := errors.List{errors.E("msg1"), errors.List{errors.E("msg2")}}
+		assert.Equal(t,
+			`errors.List{&errors.Error{Msg:"msg1"}, errors.List{&errors.Error{Msg:"msg2"}}}`,
+			fmt.Sprintf("%#v", list))
+	})
+}
@@ -1,6 +1,9 @@
 package errors
 
 import (
+	"context"
+	"sort"
+	"strconv"
 	"sync"
 )
 
@@ -8,34 +11,301 @@ import (
 // the same overall task.
 //
 // This differs from errgroup.Group in that this doesn't cancel the group when subtask
-// returns an error. Instead this accumulates errors from all subtasks in a List.
+// returns an error. Instead this accumulates errors from all subtasks in a List
+// ordered by the subtasks' launch order.
+// Cancellation on the first error could be enabled with CancelOnError option.
+//
+// Panics in subtasks are recovered and accumulated as errors created like Recover does.
+//
+// A zero Group is valid, has no limit on the number of active goroutines
+// and does not cancel on error.
 type Group struct {
-	mut  sync.Mutex
-	list List
-	wg   sync.WaitGroup
+	mut     sync.Mutex
+	failed  []Subtask
+	next    int
+	wg      sync.WaitGroup
+	sem     chan struct{}
+	repanic bool
+	panic   *Error
+
+	cancel        context.CancelCauseFunc
+	cancelOnError bool
+}
+
+// Subtask describes a failed subtask of a Group.
+type Subtask struct {
+	// Index is the launch order of the subtask in the group starting from zero.
+	Index int
+	// Op is the operation passed to Go, if any.
+	Op Op
+	// Err is the error of the subtask.
+	Err error
+}
+
+// GroupOption is an option of a Group created with WithContext.
+type GroupOption func(g *Group)
+
+// CancelOnError makes the group cancel its context the first time
+// a subtask returns an error or panics. The error becomes the context's cause.
+func CancelOnError() GroupOption {
+	return func(g *Group) {
+		g.cancelOnError = true
+	}
+}
+
+// WithContext returns a new Group and an associated Context derived from ctx.
+//
+// The derived Context is canceled when ctx is done or the first time Wait returns,
+// whichever occurs first.
+func WithContext(ctx context.Context, opts ...GroupOption) (*Group, context.Context) {
+	g := &Group{}
+	return g, g.withContext(ctx, opts)
+}
+
+func (g *Group) withContext(ctx context.Context, opts []GroupOption) context.Context {
+	ctx, g.cancel = context.WithCancelCause(ctx)
+	for _, opt := range opts {
+		opt(g)
+	}
+	return ctx
+}
+
+// SetLimit limits the number of active goroutines in this group to at most n.
+// A negative value indicates no limit.
+//
+// Any subsequent call to the Go method will block until it can add an active
+// goroutine without exceeding the configured limit.
+//
+// The limit must not be modified while any goroutines in the group are active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic("bad call to SetLimit: " + strconv.Itoa(len(g.sem)) + " goroutines are still active")
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// SetRepanic sets whether Wait should panic if any of the subtasks panicked.
+// Wait panics with the error of the first recovered panic.
+func (g *Group) SetRepanic(repanic bool) {
+	g.mut.Lock()
+	g.repanic = repanic
+	g.mut.Unlock()
 }
 
 // Go calls the given function in a new goroutine.
-func (g *Group) Go(f func() error) {
+// It blocks until the new goroutine can be added without the number of
+// active goroutines in the group exceeding the configured limit.
+//
+// An optional op labels the subtask, errors of the subtask are then wrapped with it.
+func (g *Group) Go(f func() error, op ...Op) {
+	g.acquire()
+	g.start(func(int) error { return f() }, op)
+}
+
+// TryGo calls the given function in a new goroutine only if the number of
+// active goroutines in the group is currently below the configured limit.
+//
+// The return value reports whether the goroutine was started.
+func (g *Group) TryGo(f func() error, op ...Op) bool {
+	if !g.tryAcquire() {
+		return false
+	}
+	g.start(func(int) error { return f() }, op)
+	return true
+}
+
+// Wait blocks until all function calls from the Go method have returned, then
+// returns a List of non-nil errors returned from all function calls
+// ordered by the launch order of the subtasks.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+	if g.repanic && g.panic != nil {
+		panic(g.panic)
+	}
+	var list List
+	for _, task := range g.Failed() {
+		list.Add(task.Err)
+	}
+	return list.ErrOrNil()
+}
+
+// Failed returns failed subtasks ordered by their launch order.
+// It should be called after Wait to get all of the failed subtasks.
+func (g *Group) Failed() []Subtask {
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	sort.Slice(g.failed, func(i, j int) bool {
+		return g.failed[i].Index < g.failed[j].Index
+	})
+	res := make([]Subtask, len(g.failed))
+	copy(res, g.failed)
+	return res
+}
+
+func (g *Group) acquire() {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+}
+
+func (g *Group) tryAcquire() bool {
+	if g.sem == nil {
+		return true
+	}
+	select {
+	case g.sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// start calls f with the subtask index in a new goroutine.
+func (g *Group) start(f func(index int) error, ops []Op) {
+	task := Subtask{}
+	if len(ops) > 0 {
+		task.Op = ops[0]
+	}
+	g.mut.Lock()
+	task.Index = g.next
+	g.next++
+	g.mut.Unlock()
+
 	g.wg.Add(1)
 
 	go func() {
-		defer g.wg.Done()
+		defer g.done()
+		defer func() {
+			if v := recover(); v != nil {
+				err := panicError(v, task.Op)
+				g.mut.Lock()
+				if g.panic == nil {
+					g.panic = err
+				}
+				g.mut.Unlock()
+				task.Err = err
+				g.fail(task)
+			}
+		}()
 
-		if err := f(); err != nil {
-			g.mut.Lock()
-			g.list.Add(err)
-			g.mut.Unlock()
+		if err := f(task.Index); err != nil {
+			if task.Op != "" {
+				err = E(task.Op, err)
+			}
+			task.Err = err
+			g.fail(task)
 		}
 	}()
 }
 
+func (g *Group) release() {
+	if g.sem != nil {
+		<-g.sem
+	}
+}
+
+func (g *Group) done() {
+	g.release()
+	g.wg.Done()
+}
+
+func (g *Group) fail(task Subtask) {
+	g.mut.Lock()
+	g.failed = append(g.failed, task)
+	g.mut.Unlock()
+	if g.cancelOnError && g.cancel != nil {
+		g.cancel(task.Err)
+	}
+}
+
+// GroupOf is a Group of subtasks producing results of type T.
+//
+// A zero GroupOf is valid like a zero Group.
+type GroupOf[T any] struct {
+	group   Group
+	mut     sync.Mutex
+	results []T
+}
+
+// GroupOfWithContext returns a new GroupOf and an associated Context derived from ctx
+// like WithContext does.
+func GroupOfWithContext[T any](ctx context.Context, opts ...GroupOption) (*GroupOf[T], context.Context) {
+	g := &GroupOf[T]{}
+	return g, g.group.withContext(ctx, opts)
+}
+
+// SetLimit limits the number of active goroutines in this group to at most n
+// like Group.SetLimit does.
+func (g *GroupOf[T]) SetLimit(n int) {
+	g.group.SetLimit(n)
+}
+
+// SetRepanic sets whether Wait should panic if any of the subtasks panicked
+// like Group.SetRepanic does.
+func (g *GroupOf[T]) SetRepanic(repanic bool) {
+	g.group.SetRepanic(repanic)
+}
+
+// Go calls the given function in a new goroutine like Group.Go does.
+// The result of the function is collected at the subtask's launch index.
+func (g *GroupOf[T]) Go(f func() (T, error), op ...Op) {
+	g.group.acquire()
+	g.group.start(g.collect(f), op)
+}
+
+// TryGo calls the given function in a new goroutine like Group.TryGo does.
+// The result of the function is collected at the subtask's launch index.
+func (g *GroupOf[T]) TryGo(f func() (T, error), op ...Op) bool {
+	if !g.group.tryAcquire() {
+		return false
+	}
+	g.group.start(g.collect(f), op)
+	return true
+}
+
 // Wait blocks until all function calls from the Go method have returned, then
-// returns a List of non-nil errors returned from all function calls.
-func (g *Group) Wait() error {
-	g.wg.Wait()
-	if len(g.list) == 0 {
-		return nil
+// returns their results ordered by the launch order of the subtasks and a List
+// of non-nil errors like Group.Wait does.
+//
+// Results of failed subtasks are whatever their functions returned along with
+// the error, or zero values if the subtasks panicked.
+func (g *GroupOf[T]) Wait() ([]T, error) {
+	err := g.group.Wait()
+	g.group.mut.Lock()
+	n := g.group.next
+	g.group.mut.Unlock()
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	g.grow(n)
+	return g.results, err
+}
+
+// Failed returns failed subtasks ordered by their launch order like Group.Failed does.
+func (g *GroupOf[T]) Failed() []Subtask {
+	return g.group.Failed()
+}
+
+func (g *GroupOf[T]) collect(f func() (T, error)) func(int) error {
+	return func(index int) error {
+		res, err := f()
+		g.mut.Lock()
+		g.grow(index + 1)
+		g.results[index] = res
+		g.mut.Unlock()
+		return err
+	}
+}
+
+// grow grows results to hold at least n elements.
+func (g *GroupOf[T]) grow(n int) {
+	if len(g.results) < n {
+		g.results = append(g.results, make([]T, n-len(g.results))...)
 	}
-	return g.list
 }
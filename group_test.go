@@ -1,8 +1,10 @@
 package errors_test
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -24,7 +26,7 @@ func Test_Group(t *testing.T) {
 		{"one error", []error{err1}, errors.List{err1}},
 		{"one error and nils", []error{nil, err1}, errors.List{err1}},
 		{"multiple errors", []error{err1, err2}, errors.List{err1, err2}},
-		{"multiple errors and nils", []error{err1, nil, nil, err2, nil}, errors.List{err2, err1}},
+		{"multiple errors and nils", []error{err1, nil, nil, err2, nil}, errors.List{err1, err2}},
 	}
 
 	for _, tc := range cases {
@@ -38,12 +40,290 @@ func Test_Group(t *testing.T) {
 				})
 			}
 			err := g.Wait()
-			if list, ok := err.(errors.List); ok {
-				assert.ElementsMatchf(t, tc.expected, list, "")
-			} else {
-				assert.Equal(t, tc.expected, err)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
 
+func Test_Group_Panic(t *testing.T) {
+	t.Run("recover", func(t *testing.T) {
+		err1 := errors.E("err1")
+		g := &errors.Group{}
+		g.Go(func() error { return err1 })
+		g.Go(func() error { panic("boom") })
+		g.Go(func() error { return nil })
+		err := g.Wait()
+
+		list, ok := err.(errors.List)
+		assert.True(t, ok)
+		assert.Len(t, list, 2)
+		assert.Contains(t, list, err1)
+		assert.True(t, errors.Has(err, errors.Server))
+		for _, err := range list {
+			if err != err1 {
+				assert.Equal(t, "panic: boom", err.Error())
+				assert.Equal(t, "boom", errors.Fields(err)[errors.PanicField])
+				assert.Contains(t, fmt.Sprintf("%+v", errors.Trace(err)[0]), "Test_Group_Panic")
 			}
+		}
+	})
+
+	t.Run("repanic", func(t *testing.T) {
+		g := &errors.Group{}
+		g.SetRepanic(true)
+		g.Go(func() error { return errors.E("err1") })
+		g.Go(func() error { panic("boom") })
+		assert.PanicsWithError(t, "panic: boom", func() {
+			_ = g.Wait()
 		})
-	}
+	})
+
+	t.Run("repanic without panics", func(t *testing.T) {
+		err1 := errors.E("err1")
+		g := &errors.Group{}
+		g.SetRepanic(true)
+		g.Go(func() error { return err1 })
+		assert.Equal(t, errors.List{err1}, g.Wait())
+	})
+}
+
+func Test_Group_WithContext(t *testing.T) {
+	t.Run("collects all errors", func(t *testing.T) {
+		err1, err2 := errors.E("err1"), errors.E("err2")
+		g, ctx := errors.WithContext(context.Background())
+		g.Go(func() error { return err1 })
+		g.Go(func() error { return err2 })
+		g.Go(func() error {
+			time.Sleep(10 * time.Millisecond)
+			return ctx.Err()
+		})
+		err := g.Wait()
+		assert.Equal(t, errors.List{err1, err2}, err)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("parent canceled", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		g, ctx := errors.WithContext(parent)
+		g.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		cancel()
+		assert.ElementsMatch(t, errors.List{context.Canceled}, g.Wait())
+	})
+
+	t.Run("cancel on error", func(t *testing.T) {
+		err1 := errors.E("err1")
+		g, ctx := errors.WithContext(context.Background(), errors.CancelOnError())
+		g.Go(func() error { return err1 })
+		g.Go(func() error {
+			<-ctx.Done()
+			return nil
+		})
+		assert.Equal(t, errors.List{err1}, g.Wait())
+		assert.Equal(t, err1, context.Cause(ctx))
+	})
+
+	t.Run("cancel on panic", func(t *testing.T) {
+		g, ctx := errors.WithContext(context.Background(), errors.CancelOnError())
+		g.Go(func() error { panic("boom") })
+		g.Go(func() error {
+			<-ctx.Done()
+			return nil
+		})
+		err := g.Wait()
+		assert.True(t, errors.Has(err, errors.Server))
+		assert.Equal(t, "panic: boom", context.Cause(ctx).Error())
+	})
+}
+
+func Test_Group_SetLimit(t *testing.T) {
+	t.Run("limit", func(t *testing.T) {
+		const limit = 3
+		var active, maxActive int32
+		g := &errors.Group{}
+		g.SetLimit(limit)
+		for i := 0; i < 20; i++ {
+			g.Go(func() error {
+				n := atomic.AddInt32(&active, 1)
+				for {
+					m := atomic.LoadInt32(&maxActive)
+					if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&active, -1)
+				return nil
+			})
+		}
+		assert.Nil(t, g.Wait())
+		assert.LessOrEqual(t, maxActive, int32(limit))
+	})
+
+	t.Run("try go", func(t *testing.T) {
+		g := &errors.Group{}
+		g.SetLimit(1)
+		release := make(chan struct{})
+		assert.True(t, g.TryGo(func() error {
+			<-release
+			return nil
+		}))
+		assert.False(t, g.TryGo(func() error { return nil }))
+		assert.PanicsWithValue(t, "bad call to SetLimit: 1 goroutines are still active", func() {
+			g.SetLimit(2)
+		})
+		close(release)
+		assert.Nil(t, g.Wait())
+		assert.True(t, g.TryGo(func() error { return nil }))
+		assert.Nil(t, g.Wait())
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		g := &errors.Group{}
+		g.SetLimit(-1)
+		release := make(chan struct{})
+		for i := 0; i < 10; i++ {
+			assert.True(t, g.TryGo(func() error {
+				<-release
+				return nil
+			}))
+		}
+		close(release)
+		assert.Nil(t, g.Wait())
+	})
+}
+
+func Test_Group_Failed(t *testing.T) {
+	t.Run("no failures", func(t *testing.T) {
+		g := &errors.Group{}
+		g.Go(func() error { return nil })
+		assert.Nil(t, g.Wait())
+		assert.Empty(t, g.Failed())
+	})
+
+	t.Run("ordered by index", func(t *testing.T) {
+		errs := make([]error, 10)
+		g := &errors.Group{}
+		for i := range errs {
+			i := i
+			if i%3 != 0 {
+				errs[i] = errors.E(fmt.Sprintf("err%d", i))
+			}
+			g.Go(func() error {
+				time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+				return errs[i]
+			})
+		}
+		err := g.Wait()
+		assert.Equal(t, errors.List{errs[1], errs[2], errs[4], errs[5], errs[7], errs[8]}, err)
+
+		failed := g.Failed()
+		assert.Len(t, failed, 6)
+		for _, task := range failed {
+			assert.Equal(t, errs[task.Index], task.Err)
+		}
+	})
+
+	t.Run("op", func(t *testing.T) {
+		err1 := errors.E(errors.Op("inner"), "err1", errors.NotFound)
+		g := &errors.Group{}
+		g.Go(func() error { return nil }, "task0")
+		g.Go(func() error { return err1 }, "task1")
+		g.Go(func() error { panic("boom") }, "task2")
+		err := g.Wait()
+		assert.True(t, errors.Has(err, errors.NotFound))
+
+		failed := g.Failed()
+		assert.Len(t, failed, 2)
+		assert.Equal(t, 1, failed[0].Index)
+		assert.Equal(t, errors.Op("task1"), failed[0].Op)
+		assert.Equal(t, []errors.Op{"task1", "inner"}, errors.Ops(failed[0].Err))
+		assert.Equal(t, err1, errors.Unwrap(failed[0].Err))
+		assert.Equal(t, 2, failed[1].Index)
+		assert.Equal(t, errors.Op("task2"), failed[1].Op)
+		assert.Equal(t, []errors.Op{"task2"}, errors.Ops(failed[1].Err))
+		assert.Equal(t, "panic: boom", failed[1].Err.Error())
+	})
+}
+
+func Test_GroupOf(t *testing.T) {
+	t.Run("no subtasks", func(t *testing.T) {
+		g := &errors.GroupOf[int]{}
+		res, err := g.Wait()
+		assert.Empty(t, res)
+		assert.Nil(t, err)
+	})
+
+	t.Run("results", func(t *testing.T) {
+		g := &errors.GroupOf[int]{}
+		for i := 0; i < 10; i++ {
+			i := i
+			g.Go(func() (int, error) {
+				time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+				return i * i, nil
+			})
+		}
+		res, err := g.Wait()
+		assert.Nil(t, err)
+		assert.Equal(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, res)
+	})
+
+	t.Run("partial success", func(t *testing.T) {
+		err1 := errors.E("err1", errors.NotFound)
+		g := &errors.GroupOf[string]{}
+		g.Go(func() (string, error) { return "a", nil })
+		g.Go(func() (string, error) { return "", err1 }, "task1")
+		g.Go(func() (string, error) { return "c", nil })
+		g.Go(func() (string, error) { panic("boom") })
+		res, err := g.Wait()
+		assert.Equal(t, []string{"a", "", "c", ""}, res)
+		assert.True(t, errors.Has(err, errors.NotFound))
+		assert.True(t, errors.Has(err, errors.Server))
+
+		failed := g.Failed()
+		assert.Len(t, failed, 2)
+		assert.Equal(t, 1, failed[0].Index)
+		assert.Equal(t, errors.Op("task1"), failed[0].Op)
+		assert.Equal(t, 3, failed[1].Index)
+	})
+
+	t.Run("with context", func(t *testing.T) {
+		err1 := errors.E("err1")
+		g, ctx := errors.GroupOfWithContext[int](context.Background(), errors.CancelOnError())
+		g.SetLimit(2)
+		g.Go(func() (int, error) { return 0, err1 })
+		g.Go(func() (int, error) {
+			<-ctx.Done()
+			return 1, nil
+		})
+		res, err := g.Wait()
+		assert.Equal(t, []int{0, 1}, res)
+		assert.Equal(t, errors.List{err1}, err)
+	})
+
+	t.Run("try go", func(t *testing.T) {
+		g := &errors.GroupOf[int]{}
+		g.SetLimit(1)
+		release := make(chan struct{})
+		assert.True(t, g.TryGo(func() (int, error) {
+			<-release
+			return 1, nil
+		}))
+		assert.False(t, g.TryGo(func() (int, error) { return 2, nil }))
+		close(release)
+		res, err := g.Wait()
+		assert.Equal(t, []int{1}, res)
+		assert.Nil(t, err)
+	})
+
+	t.Run("repanic", func(t *testing.T) {
+		g := &errors.GroupOf[int]{}
+		g.SetRepanic(true)
+		g.Go(func() (int, error) { panic("boom") })
+		assert.PanicsWithError(t, "panic: boom", func() {
+			_, _ = g.Wait()
+		})
+	})
 }
@@ -0,0 +1,197 @@
+package errors
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPStatuses maps error codes to HTTP status codes returned by HTTPStatus.
+// It could be modified to change the mapping or to add application codes,
+// preferably during package initialization.
+var HTTPStatuses = map[ErrorCode]int{
+	Invalid:       http.StatusBadRequest,
+	IO:            http.StatusServiceUnavailable,
+	Deadlock:      http.StatusConflict,
+	Permission:    http.StatusForbidden,
+	AlreadyExists: http.StatusConflict,
+	NotFound:      http.StatusNotFound,
+}
+
+// HTTPStatus returns HTTP status code appropriate for the error.
+//
+// The status is looked up in HTTPStatuses by the error's code. Errors with
+// codes missing from HTTPStatuses result in http.StatusBadRequest if they are
+// of Client kind, and http.StatusInternalServerError otherwise.
+// If err is nil, http.StatusOK is returned.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if status, ok := HTTPStatuses[Code(err)]; ok {
+		return status
+	}
+	if Kind(err)&Client > 0 {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// problemContentType is a media type of RFC 9457 problem details.
+const problemContentType = "application/problem+json"
+
+// problem is an RFC 9457 problem details object.
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+// WriteProblem writes err to w as an RFC 9457 problem details response.
+//
+// Response status is determined by HTTPStatus, problem type is the name of
+// the error's code and problem detail is the error's ClientMsg.
+// Messages of field violations of Client errors are written as an errors
+// member keyed by field paths.
+// Messages of non-Client errors and stack traces are never written.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	status := HTTPStatus(err)
+	p := problem{
+		Type:   Code(err).String(),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: ClientMsg(err),
+	}
+	if Kind(err)&Client > 0 {
+		p.Errors = ViolationMessages(err)
+	}
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.Path
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// RetryAfterField is a key of the field holding a time.Duration after which
+// the failed operation could be retried.
+const RetryAfterField = "retry_after"
+
+// RetryAfter returns the duration after which the failed operation could be retried,
+// if err has such a hint.
+func RetryAfter(err error) (time.Duration, bool) {
+	d, ok := Fields(err)[RetryAfterField].(time.Duration)
+	return d, ok
+}
+
+// httpCodes maps HTTP status codes of responses to error codes.
+var httpCodes = map[int]ErrorCode{
+	http.StatusBadRequest:          Invalid,
+	http.StatusUnauthorized:        Permission,
+	http.StatusForbidden:           Permission,
+	http.StatusNotFound:            NotFound,
+	http.StatusConflict:            AlreadyExists,
+	http.StatusUnprocessableEntity: Invalid,
+	http.StatusBadGateway:          IO,
+	http.StatusServiceUnavailable:  IO,
+	http.StatusGatewayTimeout:      IO,
+}
+
+// maxResponseBody is a maximum number of bytes read from the error response body.
+const maxResponseBody = 64 << 10
+
+// maxResponseMsg is a maximum length of the message taken from a plain error response body.
+const maxResponseMsg = 512
+
+// FromResponse creates an error from an unsuccessful HTTP response.
+// If the response status is less than 400, nil is returned.
+//
+// Error code is taken from the type of an RFC 9457 problem details body if it
+// names a known code, otherwise it is derived from the response status.
+// 4xx responses are of Client kind, 5xx responses are of Server kind.
+// 5xx and 429 responses are Transient, and a Retry-After header is
+// available with RetryAfter. Error op names the requested endpoint.
+//
+// FromResponse consumes the response body, but does not close it.
+func FromResponse(resp *http.Response) *Error {
+	if resp == nil || resp.StatusCode < 400 {
+		return nil
+	}
+	args := []interface{}{F("http_status", resp.StatusCode)}
+	if req := resp.Request; req != nil && req.URL != nil {
+		u := *req.URL
+		u.User, u.RawQuery, u.Fragment = nil, "", ""
+		args = append(args, Op(req.Method+" "+u.String()))
+	}
+
+	switch {
+	case resp.StatusCode >= 500:
+		args = append(args, Server, Transient)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		args = append(args, Client, Transient)
+	default:
+		args = append(args, Client)
+	}
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		args = append(args, F(RetryAfterField, d))
+	}
+
+	code := httpCodes[resp.StatusCode]
+	msg := ""
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	var p problem
+	if mediaType == problemContentType && json.Unmarshal(body, &p) == nil {
+		if c, ok := codeByName(p.Type); ok {
+			code = c
+		}
+		msg = p.Detail
+		if msg == "" {
+			msg = p.Title
+		}
+	} else if mediaType == "" || strings.HasPrefix(mediaType, "text/") {
+		msg = strings.TrimSpace(string(body))
+		if len(msg) > maxResponseMsg {
+			msg = msg[:maxResponseMsg]
+		}
+	}
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	if code != Unexpected {
+		args = append(args, code)
+	}
+	args = append(args, msg)
+	return E(args...)
+}
+
+// parseRetryAfter parses value of a Retry-After header, which is
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
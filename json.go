@@ -0,0 +1,163 @@
+package errors
+
+import (
+	"encoding/json"
+	stderr "errors"
+	"strconv"
+)
+
+// jsonNode is a JSON representation of an error in the chain.
+//
+// A node is either an *Error, a List or a KeyedList of nodes, a BoundedList,
+// a Violation, or a foreign error of which only the message is preserved.
+// Other multi-errors, like a FormattedList or errors created with errors.Join,
+// are encoded as lists of their errors.
+//
+// Kinds and codes are encoded by their names, so that they are decoded
+// correctly by programs that register kinds in a different order. Names
+// unknown to the decoding program are skipped rather than failing the decode;
+// codes fall back to their numbers.
+type jsonNode struct {
+	Op      Op                     `json:"op,omitempty"`
+	Kind    string                 `json:"kind,omitempty"`
+	Code    string                 `json:"code,omitempty"`
+	CodeNum int                    `json:"code_num,omitempty"`
+	Msg     string                 `json:"msg,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Foreign bool                   `json:"foreign,omitempty"`
+	Panic   bool                   `json:"panic,omitempty"`
+	Errors  *[]*jsonNode           `json:"errors,omitempty"`
+	Keyed   *KeyedList             `json:"keyed,omitempty"`
+	Bounded *BoundedList           `json:"bounded,omitempty"`
+	Invalid *Violation             `json:"violation,omitempty"`
+	Cause   *jsonNode              `json:"cause,omitempty"`
+	Stack   []Frame                `json:"stack,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The whole error chain is encoded, with foreign errors encoded as message-only nodes.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(encodeNode(e))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var n jsonNode
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*e = *decodeError(&n)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The list is encoded as an array of its errors.
+func (l List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(encodeList(l))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *List) UnmarshalJSON(data []byte) error {
+	var nodes []*jsonNode
+	if err := json.Unmarshal(data, &nodes); err != nil {
+		return err
+	}
+	*l = decodeList(nodes)
+	return nil
+}
+
+func encodeNode(err error) *jsonNode {
+	switch err := err.(type) {
+	case nil:
+		return nil
+	case *Error:
+		n := &jsonNode{
+			Op:     err.Op,
+			Msg:    err.Msg,
+			Fields: err.Fields,
+			Cause:  encodeNode(err.Cause),
+			Stack:  err.frames,
+			Panic:  err.panicked,
+		}
+		if err.Kind != 0 {
+			n.Kind = err.Kind.String()
+		}
+		if err.Code != 0 {
+			n.Code = err.Code.String()
+			if n.Code != strconv.Itoa(int(err.Code)) {
+				n.CodeNum = int(err.Code)
+			}
+		}
+		if err.Stack != nil {
+			n.Stack = err.Stack.Frames()
+		}
+		return n
+	case List:
+		nodes := encodeList(err)
+		return &jsonNode{Errors: &nodes}
+	case KeyedList:
+		return &jsonNode{Keyed: &err}
+	case *BoundedList:
+		return &jsonNode{Bounded: err}
+	case Violation:
+		return &jsonNode{Invalid: &err}
+	case multiError:
+		nodes := encodeList(err.Unwrap())
+		return &jsonNode{Errors: &nodes}
+	default:
+		return &jsonNode{Msg: err.Error(), Foreign: true}
+	}
+}
+
+func encodeList(l List) []*jsonNode {
+	nodes := make([]*jsonNode, len(l))
+	for i := range l {
+		nodes[i] = encodeNode(l[i])
+	}
+	return nodes
+}
+
+func decodeNode(n *jsonNode) error {
+	switch {
+	case n == nil:
+		return nil
+	case n.Errors != nil:
+		return decodeList(*n.Errors)
+	case n.Keyed != nil:
+		return *n.Keyed
+	case n.Bounded != nil:
+		return n.Bounded
+	case n.Invalid != nil:
+		return *n.Invalid
+	case n.Foreign:
+		return stderr.New(n.Msg)
+	default:
+		return decodeError(n)
+	}
+}
+
+func decodeError(n *jsonNode) *Error {
+	kind, _ := parseKind(n.Kind)
+	code, ok := parseCode(n.Code)
+	if !ok {
+		code = ErrorCode(n.CodeNum)
+	}
+	return &Error{
+		Op:       n.Op,
+		Kind:     kind,
+		Code:     code,
+		Msg:      n.Msg,
+		Fields:   n.Fields,
+		Cause:    decodeNode(n.Cause),
+		frames:   n.Stack,
+		panicked: n.Panic,
+	}
+}
+
+func decodeList(nodes []*jsonNode) List {
+	l := make(List, 0, len(nodes))
+	for _, n := range nodes {
+		l.Add(decodeNode(n))
+	}
+	return l
+}
@@ -0,0 +1,201 @@
+package errors_test
+
+import (
+	"encoding/json"
+	stderr "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_ErrorJSON(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		err := errors.E(errors.Op("op"), "msg", errors.Client, errors.NotFound, errors.F("id", "42"))
+		b, jerr := json.Marshal(err)
+		assert.NoError(t, jerr)
+
+		var res map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &res))
+		assert.Equal(t, "op", res["op"])
+		assert.Equal(t, "msg", res["msg"])
+		assert.Equal(t, map[string]interface{}{"id": "42"}, res["fields"])
+		assert.Equal(t, "Client", res["kind"])
+		assert.Equal(t, "NotFound", res["code"])
+		stack := res["stack"].([]interface{})
+		assert.NotEmpty(t, stack)
+		frame := stack[0].(map[string]interface{})
+		assert.Contains(t, frame["func"], "Test_ErrorJSON")
+		assert.Contains(t, frame["file"], "json_test.go")
+		assert.NotZero(t, frame["line"])
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		err1 := findUser(1)
+		err2 := errors.E(errors.Op("op2"), "msg2", errors.F("request_id", "abc"), err1)
+		b1, jerr := json.Marshal(err2)
+		assert.NoError(t, jerr)
+
+		res := &errors.Error{}
+		assert.NoError(t, json.Unmarshal(b1, res))
+		assert.Equal(t, err2.Error(), res.Error())
+		assert.True(t, errors.Is(res, errors.NotFound))
+		assert.True(t, errors.Is(res, errors.Client))
+		assert.Equal(t, []errors.Op{"op2", "db.findUser"}, errors.Ops(res))
+		assert.Equal(t, "user not found: 1", errors.ClientMsg(res))
+		assert.Equal(t, "abc", errors.Fields(res)["request_id"])
+
+		assert.Nil(t, errors.Trace(res))
+		frames := errors.Frames(res)
+		assert.NotEmpty(t, frames)
+		assert.Equal(t, errors.Frames(err2), frames)
+		assert.Contains(t, frames[0].Func, "findUser")
+		assert.Equal(t, fmt.Sprintf("%+v", err2), fmt.Sprintf("%+v", res))
+		assert.Contains(t, fmt.Sprintf("%+v", res), "stack:\n"+frames[0].Func+"\n\t")
+		assert.Equal(t, logStack(t, err2), logStack(t, res))
+
+		b2, jerr := json.Marshal(res)
+		assert.NoError(t, jerr)
+		assert.JSONEq(t, string(b1), string(b2))
+	})
+
+	t.Run("foreign cause", func(t *testing.T) {
+		err := errors.E(errors.Op("op"), fmt.Errorf("foreign: %w", errors.E("inner")))
+		b1, jerr := json.Marshal(err)
+		assert.NoError(t, jerr)
+
+		res := &errors.Error{}
+		assert.NoError(t, json.Unmarshal(b1, res))
+		assert.Equal(t, "foreign: inner", res.Cause.Error())
+		_, isError := res.Cause.(*errors.Error)
+		assert.False(t, isError)
+
+		b2, jerr := json.Marshal(res)
+		assert.NoError(t, jerr)
+		assert.JSONEq(t, string(b1), string(b2))
+	})
+
+	t.Run("list cause", func(t *testing.T) {
+		list := errors.List{errors.E("msg1", errors.Invalid), fmt.Errorf("msg2")}
+		err := errors.E(errors.Op("op"), "batch", list)
+		b1, jerr := json.Marshal(err)
+		assert.NoError(t, jerr)
+
+		res := &errors.Error{}
+		assert.NoError(t, json.Unmarshal(b1, res))
+		resList, ok := res.Cause.(errors.List)
+		assert.True(t, ok)
+		assert.Len(t, resList, 2)
+		assert.True(t, errors.Has(resList, errors.Invalid))
+
+		b2, jerr := json.Marshal(res)
+		assert.NoError(t, jerr)
+		assert.JSONEq(t, string(b1), string(b2))
+	})
+
+	t.Run("multi-error causes", func(t *testing.T) {
+		list := errors.List{errors.E("msg1", errors.Invalid), fmt.Errorf("msg2")}
+		bounded := errors.NewBoundedList(1, 0)
+		for _, e := range list {
+			bounded.Add(e)
+		}
+		causes := map[string]error{
+			"formatted": list.WithFormatter(errors.SingleLineListFormatter),
+			"joined":    stderr.Join(list...),
+			"bounded":   bounded,
+		}
+		for name, cause := range causes {
+			t.Run(name, func(t *testing.T) {
+				b, jerr := json.Marshal(errors.E(errors.Op("op"), "batch failed", cause))
+				assert.NoError(t, jerr)
+				res := &errors.Error{}
+				assert.NoError(t, json.Unmarshal(b, res))
+				assert.True(t, errors.Is(res, errors.Invalid))
+				assert.True(t, errors.Has(res.Cause, errors.Invalid))
+				assert.Equal(t, "msg1", errors.Multiple(res.Cause)[0].Error())
+			})
+		}
+
+		b, jerr := json.Marshal(errors.E(bounded))
+		assert.NoError(t, jerr)
+		res := &errors.Error{}
+		assert.NoError(t, json.Unmarshal(b, res))
+		assert.Equal(t, bounded.Error(), res.Cause.Error())
+		assert.Equal(t, 1, res.Cause.(*errors.BoundedList).Dropped())
+	})
+
+	t.Run("codes and kinds", func(t *testing.T) {
+		err := errors.E("quota", errors.Client, kindRetryable, codeQuotaExceeded)
+		b, jerr := json.Marshal(err)
+		assert.NoError(t, jerr)
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &obj))
+		assert.Equal(t, "Client|Retryable", obj["kind"])
+		assert.Equal(t, "QuotaExceeded", obj["code"])
+		assert.Equal(t, float64(codeQuotaExceeded), obj["code_num"])
+
+		b, jerr = json.Marshal(&errors.Error{Code: codeUnregistered})
+		assert.NoError(t, jerr)
+		assert.JSONEq(t, `{"code":"101"}`, string(b))
+	})
+
+	t.Run("unknown codes and kinds", func(t *testing.T) {
+		res := &errors.Error{}
+		data := `{"kind":"Client|Fatal","code":"Conflict","code_num":409,"msg":"conflict","cause":{"code":"Missing","msg":"cause"}}`
+		assert.NoError(t, json.Unmarshal([]byte(data), res))
+		assert.Equal(t, "conflict", res.Msg)
+		assert.Equal(t, errors.Client, res.Kind)
+		assert.Equal(t, errors.ErrorCode(409), res.Code)
+		assert.Equal(t, errors.Unexpected, res.Cause.(*errors.Error).Code)
+		assert.Equal(t, "conflict: cause", res.Error())
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		res := &errors.Error{}
+		assert.Error(t, json.Unmarshal([]byte(`[]`), res))
+	})
+}
+
+func Test_ListJSON(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		b, err := json.Marshal(errors.List{})
+		assert.NoError(t, err)
+		assert.Equal(t, "[]", string(b))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		nested := errors.List{fmt.Errorf("msg3")}
+		list := errors.List{errors.E("msg1", errors.Client, errors.Invalid), fmt.Errorf("msg2"), nested}
+		b1, err := json.Marshal(list)
+		assert.NoError(t, err)
+
+		var res errors.List
+		assert.NoError(t, json.Unmarshal(b1, &res))
+		assert.Len(t, res, 3)
+		assert.True(t, errors.Has(res, errors.Invalid))
+		assert.Equal(t, "msg2", res[1].Error())
+		assert.Equal(t, errors.List{fmt.Errorf("msg3")}.Error(), res[2].Error())
+
+		b2, err := json.Marshal(res)
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(b1), string(b2))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		var res errors.List
+		assert.Error(t, json.Unmarshal([]byte(`{}`), &res))
+	})
+}
+
+func logStack(t *testing.T, err error) interface{} {
+	t.Helper()
+	rec := logRecord(t, func(w io.Writer) slog.Handler {
+		return errors.NewHandler(slog.NewJSONHandler(w, nil), &errors.HandlerOptions{Stack: true})
+	}, err)
+	stack := rec["err"].(map[string]interface{})["stack"]
+	assert.NotEmpty(t, stack)
+	return stack
+}
@@ -0,0 +1,166 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// KeyedError is an error associated with a key of an item it belongs to,
+// like an index or an identifier.
+type KeyedError struct {
+	Key interface{}
+	Err error
+}
+
+// Error returns human readable representation of an error prefixed with its key.
+func (e KeyedError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the keyed error.
+func (e KeyedError) Unwrap() error {
+	return e.Err
+}
+
+// KeyedList is an error type that can hold multiple errors keyed by items they belong to.
+// It could be used to return errors of batch operations.
+type KeyedList []KeyedError
+
+// Add adds an error with the given key to list.
+func (l *KeyedList) Add(key interface{}, err error) {
+	if err != nil {
+		*l = append(*l, KeyedError{Key: key, Err: err})
+	}
+}
+
+// ErrOrNil return nil if error list is empty, otherwise list itself.
+func (l KeyedList) ErrOrNil() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Get returns an error with the given key, or nil if there is no such error.
+// Keys are compared with ==, except for uncomparable ones, like slices,
+// which are compared with reflect.DeepEqual.
+func (l KeyedList) Get(key interface{}) error {
+	for i := range l {
+		if keysEqual(l[i].Key, key) {
+			return l[i].Err
+		}
+	}
+	return nil
+}
+
+func keysEqual(a, b interface{}) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	if a == nil {
+		return true
+	}
+	if reflect.ValueOf(a).Comparable() && reflect.ValueOf(b).Comparable() {
+		return a == b
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// Errors returns errors of the list without their keys.
+func (l KeyedList) Errors() List {
+	res := make(List, len(l))
+	for i := range l {
+		res[i] = l[i].Err
+	}
+	return res
+}
+
+// Unwrap returns keyed errors of the list.
+func (l KeyedList) Unwrap() []error {
+	res := make([]error, len(l))
+	for i := range l {
+		res[i] = l[i]
+	}
+	return res
+}
+
+// Error returns human readable representation of all errors in the list
+// prefixed with their keys, or empty string if list is empty.
+func (l KeyedList) Error() string {
+	msgs := make([]string, len(l))
+	for i := range l {
+		msgs[i] = l[i].Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// MarshalJSON implements json.Marshaler.
+// The list is encoded as an object of errors keyed by their keys formatted with fmt.Sprint.
+// Errors with keys formatted the same way, like 1 and "1", are encoded as a single
+// member holding a List of them, at the position of the first one.
+func (l KeyedList) MarshalJSON() ([]byte, error) {
+	keys := make([]string, 0, len(l))
+	errs := make(map[string]List, len(l))
+	for i := range l {
+		key := fmt.Sprint(l[i].Key)
+		if _, ok := errs[key]; !ok {
+			keys = append(keys, key)
+		}
+		errs[key] = append(errs[key], l[i].Err)
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		b, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+		buf.WriteByte(':')
+		var e error = errs[key]
+		if len(errs[key]) == 1 {
+			e = errs[key][0]
+		}
+		node, err := json.Marshal(encodeNode(e))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(node)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Keys of the decoded list are strings, the order of errors is preserved.
+func (l *KeyedList) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return E(Invalid, "keyed error list must be a json object")
+	}
+	res := KeyedList{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var n jsonNode
+		if err := dec.Decode(&n); err != nil {
+			return err
+		}
+		res.Add(tok.(string), decodeNode(&n))
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	*l = res
+	return nil
+}
@@ -0,0 +1,170 @@
+package errors_test
+
+import (
+	"encoding/json"
+	stderr "errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_KeyedList(t *testing.T) {
+	newList := func() errors.KeyedList {
+		list := errors.KeyedList{}
+		list.Add("row1", fmt.Errorf("error one"))
+		list.Add("row2", nil)
+		list.Add(3, fs.ErrClosed)
+		list.Add("row4", someError{code: 444})
+		list.Add(5, errors.E(errors.Op("op"), "err5", errors.Client, errors.Invalid))
+		return list
+	}
+
+	t.Run("add", func(t *testing.T) {
+		list := newList()
+		assert.Len(t, list, 4)
+		assert.Equal(t, fs.ErrClosed, list.Get(3))
+		assert.Nil(t, list.Get("row2"))
+		assert.Nil(t, list.Get("3"))
+	})
+
+	t.Run("err or nil", func(t *testing.T) {
+		assert.Nil(t, errors.KeyedList{}.ErrOrNil())
+		list := newList()
+		assert.Equal(t, list, list.ErrOrNil())
+	})
+
+	t.Run("message", func(t *testing.T) {
+		assert.Equal(t, "", errors.KeyedList{}.Error())
+		assert.Equal(t, "row1: error one; 3: file already closed; row4: Error 444; 5: err5", newList().Error())
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+		list := newList()
+		assert.Equal(t, []error{list[0].Err, list[1].Err, list[2].Err, list[3].Err}, errors.Multiple(list))
+	})
+
+	t.Run("has", func(t *testing.T) {
+		var err error = newList()
+		assert.True(t, errors.Has(err, errors.Client))
+		assert.True(t, errors.Has(err, errors.Invalid))
+		assert.True(t, errors.Has(err, fs.ErrClosed))
+		assert.False(t, errors.Has(err, errors.Server))
+		assert.False(t, errors.Has(err, fs.ErrNotExist))
+		assert.False(t, errors.Has(errors.KeyedList{}, errors.Client))
+
+		assert.True(t, errors.HasAnyOf(err, errors.Server, errors.Invalid))
+		assert.False(t, errors.HasAnyOf(err, errors.Server, errors.NotFound))
+		assert.False(t, errors.HasAnyOf(errors.KeyedList{}, errors.Client))
+	})
+
+	t.Run("is", func(t *testing.T) {
+		var err error = newList()
+		assert.True(t, errors.Is(err, errors.Client))
+		assert.True(t, errors.Is(err, fs.ErrClosed))
+		assert.False(t, errors.Is(err, errors.IO))
+		assert.True(t, errors.Is(errors.E("wrapped", err), errors.Invalid))
+
+		assert.True(t, stderr.Is(err, fs.ErrClosed))
+		assert.False(t, stderr.Is(err, fs.ErrNotExist))
+	})
+
+	t.Run("as", func(t *testing.T) {
+		var err error = newList()
+		var res1 someError
+		var res2 *errors.Error
+		var res3 otherError
+		var res4 errors.KeyedError
+
+		assert.True(t, errors.As(err, &res1))
+		assert.Equal(t, 444, res1.code)
+		assert.True(t, errors.As(err, &res2))
+		assert.Equal(t, "err5", res2.Msg)
+		assert.False(t, errors.As(err, &res3))
+
+		assert.True(t, stderr.As(err, &res1))
+		assert.True(t, stderr.As(err, &res2))
+		assert.True(t, stderr.As(err, &res4))
+		assert.Equal(t, "row1", res4.Key)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		list := newList()
+		b1, err := json.Marshal(list)
+		assert.NoError(t, err)
+
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b1, &obj))
+		assert.Len(t, obj, 4)
+		assert.Equal(t, map[string]interface{}{"msg": "file already closed", "foreign": true}, obj["3"])
+		assert.Equal(t, "err5", obj["5"].(map[string]interface{})["msg"])
+
+		var res errors.KeyedList
+		assert.NoError(t, json.Unmarshal(b1, &res))
+		assert.Len(t, res, 4)
+		assert.Equal(t, []interface{}{"row1", "3", "row4", "5"}, []interface{}{res[0].Key, res[1].Key, res[2].Key, res[3].Key})
+		assert.True(t, errors.Is(res.Get("5"), errors.Invalid))
+		assert.Equal(t, list.Error(), res.Error())
+
+		b2, err := json.Marshal(res)
+		assert.NoError(t, err)
+		assert.Equal(t, string(b1), string(b2))
+	})
+
+	t.Run("json cause", func(t *testing.T) {
+		err := errors.E(errors.Op("op"), "import failed", newList())
+		b1, jerr := json.Marshal(err)
+		assert.NoError(t, jerr)
+
+		res := &errors.Error{}
+		assert.NoError(t, json.Unmarshal(b1, res))
+		assert.True(t, errors.Is(res, errors.Invalid))
+		_, ok := res.Cause.(errors.KeyedList)
+		assert.True(t, ok)
+
+		b2, jerr := json.Marshal(res)
+		assert.NoError(t, jerr)
+		assert.JSONEq(t, string(b1), string(b2))
+	})
+
+	t.Run("uncomparable keys", func(t *testing.T) {
+		list := errors.KeyedList{}
+		list.Add([]int{1, 2}, fs.ErrClosed)
+		list.Add(map[string]int{"a": 1}, fs.ErrExist)
+		list.Add(3, fs.ErrNotExist)
+		assert.NotPanics(t, func() {
+			assert.Equal(t, fs.ErrClosed, list.Get([]int{1, 2}))
+			assert.Equal(t, fs.ErrExist, list.Get(map[string]int{"a": 1}))
+			assert.Equal(t, fs.ErrNotExist, list.Get(3))
+			assert.Nil(t, list.Get([]int{1}))
+			assert.Nil(t, list.Get(nil))
+		})
+	})
+
+	t.Run("json colliding keys", func(t *testing.T) {
+		list := errors.KeyedList{}
+		list.Add(1, fs.ErrClosed)
+		list.Add("2", fs.ErrExist)
+		list.Add("1", fs.ErrNotExist)
+		b, err := json.Marshal(list)
+		assert.NoError(t, err)
+
+		var res errors.KeyedList
+		assert.NoError(t, json.Unmarshal(b, &res))
+		assert.Len(t, res, 2)
+		assert.Equal(t, "1", res[0].Key)
+		assert.Equal(t, "2", res[1].Key)
+		errs := errors.Multiple(res.Get("1"))
+		assert.Len(t, errs, 2)
+		assert.Equal(t, fs.ErrClosed.Error(), errs[0].Error())
+		assert.Equal(t, fs.ErrNotExist.Error(), errs[1].Error())
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		var res errors.KeyedList
+		assert.Error(t, json.Unmarshal([]byte(`[]`), &res))
+		assert.Error(t, json.Unmarshal([]byte(`{"a":1}`), &res))
+	})
+}
@@ -4,30 +4,35 @@ import (
 	stderr "errors"
 )
 
+// multiError is an error that wraps multiple errors.
+type multiError interface {
+	Unwrap() []error
+}
+
 // List is an error type that can hold multiple errors.
 // It could be used to return accumulated errors from the function
 // as a single error.
 type List []error
 
-// Multiple returns slice of errors if err is a List, slice with one error err otherwise.
+// Multiple returns slice of errors if err is a List, a KeyedList or any other
+// multi-error with an Unwrap() []error method, like the one created with errors.Join
+// from the standard library. Otherwise it returns slice with one error err.
 func Multiple(err error) []error {
-	if list, ok := err.(List); ok {
+	switch list := err.(type) {
+	case List:
 		return list
+	case KeyedList:
+		return list.Errors()
+	case multiError:
+		return list.Unwrap()
 	}
 	return []error{err}
 }
 
 // Has checks if err contains an error of given ErrorKind, with given ErrorCode or matches given error target.
 func Has(err error, target interface{}) bool {
-	list, ok := err.(List)
-	if !ok {
-		return Is(err, target)
-	}
-	if len(list) == 0 {
-		return false
-	}
-	for i := range list {
-		if Is(list[i], target) {
+	for _, e := range Multiple(err) {
+		if Is(e, target) {
 			return true
 		}
 	}
@@ -71,37 +76,138 @@ func (l *List) Clear() {
 	*l = (*l)[:0]
 }
 
-func (l List) Unwrap() error {
-	if l == nil || len(l) == 0 {
-		return nil
-	}
-	if len(l) == 1 {
-		return l[0]
-	}
-	rest := make(List, len(l)-1)
-	copy(rest, l[1:])
-	return &rest
+// Unwrap returns errors of the list.
+// It allows errors.Is and errors.As from the standard library to examine every error in the list.
+func (l List) Unwrap() []error {
+	return l
 }
 
+// Is reports whether any error in the list matches target.
 func (l List) Is(target error) bool {
-	if l == nil || len(l) == 0 {
-		return false
+	for i := range l {
+		if stderr.Is(l[i], target) {
+			return true
+		}
 	}
-	return stderr.Is(l[0], target)
+	return false
 }
 
+// As finds the first error in the list that matches target, and if so, sets
+// target to that error value and returns true. Otherwise, it returns false.
 func (l List) As(target interface{}) bool {
-	if l == nil || len(l) == 0 {
-		return false
+	for i := range l {
+		if stderr.As(l[i], target) {
+			return true
+		}
 	}
-	return stderr.As(l[0], target)
+	return false
 }
 
-// Errors returns human readable representation of first error in the list
-// or empty string if list is empty.
+// Error returns human readable representation of all errors in the list
+// formatted with DefaultListFormatter.
 func (l List) Error() string {
-	if l == nil || len(l) == 0 {
-		return ""
+	return DefaultListFormatter(l)
+}
+
+// WithFormatter returns the list with its message formatted by f.
+func (l List) WithFormatter(f ListFormatter) FormattedList {
+	return FormattedList{List: l, Formatter: f}
+}
+
+// FormattedList is a List with its own ListFormatter.
+type FormattedList struct {
+	List
+	Formatter ListFormatter
+}
+
+// Error returns human readable representation of all errors in the list
+// formatted with the list's formatter, or DefaultListFormatter if it is nil.
+func (l FormattedList) Error() string {
+	if l.Formatter == nil {
+		return l.List.Error()
+	}
+	return l.Formatter(l.List)
+}
+
+// ErrOrNil return nil if error list is empty, otherwise list itself.
+func (l FormattedList) ErrOrNil() error {
+	if len(l.List) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Filter returns errors of the list that are of given ErrorKind, with given ErrorCode,
+// match given error target or satisfy given predicate of type func(error) bool.
+func (l List) Filter(what interface{}) List {
+	matched, _ := l.Partition(what)
+	return matched
+}
+
+// Partition splits the list into errors that are of given ErrorKind, with given ErrorCode,
+// match given error target or satisfy given predicate of type func(error) bool,
+// and the rest of the errors.
+func (l List) Partition(what interface{}) (matched, rest List) {
+	pred, ok := what.(func(error) bool)
+	if !ok {
+		pred = func(err error) bool {
+			return Is(err, what)
+		}
+	}
+	for i := range l {
+		if pred(l[i]) {
+			matched.Add(l[i])
+		} else {
+			rest.Add(l[i])
+		}
+	}
+	return matched, rest
+}
+
+// GroupByCode groups errors of the list by their ErrorCode.
+func (l List) GroupByCode() map[ErrorCode]List {
+	res := make(map[ErrorCode]List)
+	for i := range l {
+		code := Code(l[i])
+		res[code] = append(res[code], l[i])
+	}
+	return res
+}
+
+// Dedup returns the list without duplicate errors, keeping the first one of them.
+// Errors are duplicates if they have the same code, outermost operation and message.
+func (l List) Dedup() List {
+	type key struct {
+		code ErrorCode
+		op   Op
+		msg  string
+	}
+	seen := make(map[key]struct{}, len(l))
+	var res List
+	for i := range l {
+		k := key{code: Code(l[i]), msg: l[i].Error()}
+		if ops := Ops(l[i]); len(ops) > 0 {
+			k.op = ops[0]
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		res.Add(l[i])
+	}
+	return res
+}
+
+// Flatten returns the list with errors of nested Lists, KeyedLists and other multi-errors,
+// like the ones created with errors.Join from the standard library, lifted to a single level.
+func (l List) Flatten() List {
+	var res List
+	for i := range l {
+		if _, ok := l[i].(multiError); ok {
+			res = append(res, List(Multiple(l[i])).Flatten()...)
+			continue
+		}
+		res.Add(l[i])
 	}
-	return l[0].Error()
+	return res
 }
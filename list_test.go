@@ -4,6 +4,7 @@ import (
 	stderr "errors"
 	"fmt"
 	"io/fs"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -140,6 +141,13 @@ func Test_ListIs(t *testing.T) {
 		assert.True(t, stderr.Is(err, fs.ErrClosed))
 		assert.False(t, stderr.Is(err, fs.ErrNotExist))
 	})
+
+	t.Run("method", func(t *testing.T) {
+		list := errors.List{fmt.Errorf("err1"), fmt.Errorf("wrapped: %w", fs.ErrClosed)}
+		assert.True(t, list.Is(fs.ErrClosed))
+		assert.False(t, list.Is(fs.ErrNotExist))
+		assert.False(t, errors.List{}.Is(fs.ErrClosed))
+	})
 }
 
 func Test_ListAs(t *testing.T) {
@@ -232,6 +240,168 @@ func Test_List_ErrorOrNil(t *testing.T) {
 	})
 }
 
+func Test_List_Unwrap(t *testing.T) {
+	list := errors.List{fmt.Errorf("err1"), fs.ErrClosed, someError{code: 444}}
+	assert.Equal(t, []error(list), list.Unwrap())
+	assert.Nil(t, errors.Unwrap(list))
+
+	var res someError
+	assert.True(t, stderr.Is(list, fs.ErrClosed))
+	assert.True(t, stderr.As(list, &res))
+	assert.Equal(t, 444, res.code)
+
+	res = someError{}
+	assert.True(t, list.As(&res))
+	assert.Equal(t, 444, res.code)
+	var pathErr *fs.PathError
+	assert.False(t, list.As(&pathErr))
+}
+
+func Test_JoinedErrors(t *testing.T) {
+	err1 := fmt.Errorf("err1")
+	err2 := errors.E(errors.Op("op"), "err2", errors.Client, errors.Invalid)
+	err3 := someError{code: 444}
+	joined := stderr.Join(err1, err2, err3)
+
+	t.Run("multiple", func(t *testing.T) {
+		assert.Equal(t, []error{err1, err2, err3}, errors.Multiple(joined))
+	})
+
+	t.Run("has", func(t *testing.T) {
+		assert.True(t, errors.Has(joined, errors.Client))
+		assert.True(t, errors.Has(joined, errors.Invalid))
+		assert.True(t, errors.Has(joined, err1))
+		assert.False(t, errors.Has(joined, errors.Server))
+		assert.False(t, errors.Has(joined, fs.ErrClosed))
+		assert.True(t, errors.HasAnyOf(joined, errors.Server, errors.Invalid))
+	})
+
+	t.Run("is", func(t *testing.T) {
+		assert.True(t, errors.Is(joined, errors.Client))
+		assert.True(t, errors.Is(joined, err2))
+		assert.False(t, errors.Is(joined, errors.NotFound))
+		assert.True(t, errors.Is(errors.E("wrapped", joined), errors.Invalid))
+	})
+
+	t.Run("as", func(t *testing.T) {
+		var res1 someError
+		var res2 *errors.Error
+		var res3 otherError
+		assert.True(t, errors.As(joined, &res1))
+		assert.Equal(t, 444, res1.code)
+		assert.True(t, errors.As(joined, &res2))
+		assert.Equal(t, "err2", res2.Msg)
+		assert.False(t, errors.As(joined, &res3))
+	})
+
+	t.Run("list of joined", func(t *testing.T) {
+		list := errors.List{fmt.Errorf("err0"), joined}
+		assert.True(t, errors.Has(list, errors.Invalid))
+		assert.True(t, stderr.Is(list, err1))
+	})
+}
+
+func Test_List_Query(t *testing.T) {
+	err1 := errors.E(errors.Op("op1"), "err1", errors.Client, errors.NotFound)
+	err2 := errors.E(errors.Op("op2"), "err2", errors.Server, errors.Transient, errors.IO)
+	err3 := fs.ErrClosed
+	err4 := errors.E(errors.Op("op4"), "err4", errors.Client, errors.Invalid)
+	err5 := errors.E(errors.Op("op5"), "err5", errors.Server, errors.IO)
+	list := errors.List{err1, err2, err3, err4, err5}
+
+	t.Run("filter", func(t *testing.T) {
+		assert.Equal(t, errors.List{err1, err4}, list.Filter(errors.Client))
+		assert.Equal(t, errors.List{err2, err5}, list.Filter(errors.IO))
+		assert.Equal(t, errors.List{err3}, list.Filter(fs.ErrClosed))
+		assert.Equal(t, errors.List{err2}, list.Filter(func(err error) bool {
+			return strings.HasSuffix(err.Error(), "2")
+		}))
+		assert.Nil(t, list.Filter(errors.Deadlock))
+		assert.Nil(t, errors.List{}.Filter(errors.Client).ErrOrNil())
+		assert.PanicsWithValue(t, "what must be ErrorKind, ErrorCode or error", func() {
+			list.Filter(42)
+		})
+	})
+
+	t.Run("partition", func(t *testing.T) {
+		transient, permanent := list.Partition(errors.Transient)
+		assert.Equal(t, errors.List{err2}, transient)
+		assert.Equal(t, errors.List{err1, err3, err4, err5}, permanent)
+	})
+
+	t.Run("group by code", func(t *testing.T) {
+		assert.Equal(t, map[errors.ErrorCode]errors.List{
+			errors.NotFound:   {err1},
+			errors.IO:         {err2, err5},
+			errors.Unexpected: {err3},
+			errors.Invalid:    {err4},
+		}, list.GroupByCode())
+		assert.Empty(t, errors.List{}.GroupByCode())
+	})
+
+	t.Run("dedup", func(t *testing.T) {
+		dup1 := errors.E(errors.Op("op1"), "err1", errors.Client, errors.NotFound)
+		otherOp := errors.E(errors.Op("op0"), "err1", errors.Client, errors.NotFound)
+		otherCode := errors.E(errors.Op("op1"), "err1", errors.Client, errors.Invalid)
+		otherMsg := errors.E(errors.Op("op1"), "other", errors.Client, errors.NotFound)
+		list := errors.List{err1, err3, dup1, otherOp, otherCode, otherMsg, fs.ErrClosed, err1}
+		assert.Equal(t, errors.List{err1, err3, otherOp, otherCode, otherMsg}, list.Dedup())
+	})
+
+	t.Run("flatten", func(t *testing.T) {
+		keyed := errors.KeyedList{}
+		keyed.Add("key", err5)
+		nested := errors.List{
+			err1,
+			errors.List{err2, errors.List{err3}},
+			stderr.Join(err4, keyed),
+			errors.List{}.WithFormatter(errors.SingleLineListFormatter),
+		}
+		assert.Equal(t, list, nested.Flatten())
+		assert.Equal(t, list, list.Flatten())
+	})
+}
+
+func Benchmark_List_Traversal(b *testing.B) {
+	target := fs.ErrClosed
+	for _, n := range []int{10, 100, 1000} {
+		list := make(errors.List, n)
+		for i := range list {
+			list[i] = fmt.Errorf("err%d", i)
+		}
+		list[n-1] = target
+		var err error = list
+
+		b.Run(fmt.Sprintf("stdlib Is/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !stderr.Is(err, target) {
+					b.Fatal("target not found")
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("stdlib As/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			var res *errors.Error
+			for i := 0; i < b.N; i++ {
+				if stderr.As(err, &res) {
+					b.Fatal("unexpected target")
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("Has/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !errors.Has(err, target) {
+					b.Fatal("target not found")
+				}
+			}
+		})
+	}
+}
+
 type otherError struct{}
 
 func (otherError) Error() string {
@@ -0,0 +1,101 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ListFormatter formats a message of a list of errors.
+type ListFormatter func(errs []error) string
+
+// DefaultListFormatter formats messages of lists without a formatter of their own.
+// It could be changed to alter messages of all lists, preferably during program initialization.
+var DefaultListFormatter ListFormatter = BulletListFormatter
+
+// BulletListFormatter formats messages of errors as a bulleted list:
+//
+//	3 errors occurred:
+//		* error one
+//		* error two
+//		* error three
+//
+// A message of the only error is returned as is. Empty list results in empty message.
+func BulletListFormatter(errs []error) string {
+	return formatList(errs, len(errs), func(int) string { return "* " })
+}
+
+// NumberedListFormatter formats messages of errors as a numbered list:
+//
+//	3 errors occurred:
+//		1. error one
+//		2. error two
+//		3. error three
+//
+// A message of the only error is returned as is. Empty list results in empty message.
+func NumberedListFormatter(errs []error) string {
+	return formatList(errs, len(errs), func(i int) string { return strconv.Itoa(i+1) + ". " })
+}
+
+// SingleLineListFormatter formats messages of errors on a single line:
+//
+//	3 errors occurred: error one; error two; error three
+//
+// A message of the only error is returned as is. Empty list results in empty message.
+func SingleLineListFormatter(errs []error) string {
+	if len(errs) <= 1 {
+		return formatList(errs, 1, nil)
+	}
+	sb := &strings.Builder{}
+	sb.WriteString(errorsOccurred(len(errs)))
+	sb.WriteString(": ")
+	for i := range errs {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(errs[i].Error())
+	}
+	return sb.String()
+}
+
+// TruncatedListFormatter returns a ListFormatter that formats messages of
+// at most n errors like BulletListFormatter does, followed by the number of
+// omitted errors:
+//
+//	5 errors occurred:
+//		* error one
+//		* error two
+//		... and 3 more errors
+func TruncatedListFormatter(n int) ListFormatter {
+	return func(errs []error) string {
+		return formatList(errs, n, func(int) string { return "* " })
+	}
+}
+
+// formatList formats messages of at most n errors prefixing them with bullet.
+func formatList(errs []error, n int, bullet func(i int) string) string {
+	switch len(errs) {
+	case 0:
+		return ""
+	case 1:
+		return errs[0].Error()
+	}
+	sb := &strings.Builder{}
+	sb.WriteString(errorsOccurred(len(errs)))
+	sb.WriteString(":")
+	for i := range errs {
+		if i >= n {
+			sb.WriteString("\n\t... and ")
+			sb.WriteString(strconv.Itoa(len(errs) - n))
+			sb.WriteString(" more errors")
+			break
+		}
+		sb.WriteString("\n\t")
+		sb.WriteString(bullet(i))
+		sb.WriteString(strings.ReplaceAll(errs[i].Error(), "\n", "\n\t"))
+	}
+	return sb.String()
+}
+
+func errorsOccurred(n int) string {
+	return strconv.Itoa(n) + " errors occurred"
+}
@@ -0,0 +1,85 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_List_Error(t *testing.T) {
+	list := errors.List{fmt.Errorf("err1"), errors.E("err2"), fmt.Errorf("err3")}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, "", errors.List{}.Error())
+	})
+
+	t.Run("one error", func(t *testing.T) {
+		assert.Equal(t, "err1", errors.List{fmt.Errorf("err1")}.Error())
+	})
+
+	t.Run("several errors", func(t *testing.T) {
+		assert.Equal(t, "3 errors occurred:\n\t* err1\n\t* err2\n\t* err3", list.Error())
+	})
+
+	t.Run("nested list", func(t *testing.T) {
+		nested := errors.List{fmt.Errorf("err1"), errors.List{fmt.Errorf("err2"), fmt.Errorf("err3")}}
+		assert.Equal(t, "2 errors occurred:\n\t* err1\n\t* 2 errors occurred:\n\t\t* err2\n\t\t* err3", nested.Error())
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		err := errors.E("batch failed", list)
+		assert.Equal(t, "batch failed: 3 errors occurred:\n\t* err1\n\t* err2\n\t* err3", err.Error())
+	})
+
+	t.Run("default formatter", func(t *testing.T) {
+		defer func(f errors.ListFormatter) { errors.DefaultListFormatter = f }(errors.DefaultListFormatter)
+		errors.DefaultListFormatter = errors.SingleLineListFormatter
+		assert.Equal(t, "3 errors occurred: err1; err2; err3", list.Error())
+	})
+}
+
+func Test_ListFormatter(t *testing.T) {
+	list := errors.List{fmt.Errorf("err1"), errors.E("err2"), fmt.Errorf("err3")}
+
+	cases := []struct {
+		name     string
+		f        errors.ListFormatter
+		expected string
+	}{
+		{"bullet", errors.BulletListFormatter, "3 errors occurred:\n\t* err1\n\t* err2\n\t* err3"},
+		{"numbered", errors.NumberedListFormatter, "3 errors occurred:\n\t1. err1\n\t2. err2\n\t3. err3"},
+		{"single line", errors.SingleLineListFormatter, "3 errors occurred: err1; err2; err3"},
+		{"truncated", errors.TruncatedListFormatter(1), "3 errors occurred:\n\t* err1\n\t... and 2 more errors"},
+		{"truncated over length", errors.TruncatedListFormatter(5), "3 errors occurred:\n\t* err1\n\t* err2\n\t* err3"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.f(list))
+			assert.Equal(t, "", tc.f(nil))
+			assert.Equal(t, "err1", tc.f(list[:1]))
+		})
+	}
+}
+
+func Test_FormattedList(t *testing.T) {
+	list := errors.List{fmt.Errorf("err1"), errors.E("err2", errors.Invalid)}
+
+	t.Run("message", func(t *testing.T) {
+		err := list.WithFormatter(errors.NumberedListFormatter)
+		assert.Equal(t, "2 errors occurred:\n\t1. err1\n\t2. err2", err.Error())
+		assert.Equal(t, err.Error(), fmt.Sprintf("%v", err))
+		assert.Equal(t, list.Error(), errors.FormattedList{List: list}.Error())
+	})
+
+	t.Run("list semantics", func(t *testing.T) {
+		err := list.WithFormatter(errors.SingleLineListFormatter)
+		err.Add(fmt.Errorf("err3"))
+		assert.Len(t, errors.Multiple(err), 3)
+		assert.True(t, errors.Has(err, errors.Invalid))
+		assert.True(t, errors.Is(err, errors.Invalid))
+		assert.Equal(t, "3 errors occurred: err1; err2; err3", err.ErrOrNil().Error())
+		assert.Nil(t, errors.List{}.WithFormatter(errors.SingleLineListFormatter).ErrOrNil())
+	})
+}
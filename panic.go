@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PanicField is a key of the field holding a non-error value passed to panic.
+const PanicField = "panic"
+
+// Recover converts a panic into an error stored in *errp.
+// It must be called directly by a deferred function call:
+//
+//	func DoSmth() (err error) {
+//		defer errors.Recover(&err, op)
+//		// ...
+//	}
+//
+// The error is of Server kind and Unexpected code, its stack trace is captured at
+// the panic site. If the panic value is an error, it becomes a cause of the error,
+// otherwise the value is available in the PanicField field. The code is Unexpected
+// even if the panic value has a code of its own, though the panic value itself
+// is still matched by Is and As.
+// If there is no panic, *errp is left unchanged.
+func Recover(errp *error, op Op) {
+	if v := recover(); v != nil {
+		*errp = panicError(v, op)
+	}
+}
+
+// Catch calls f and converts its panic, if any, into an error like Recover does.
+func Catch(f func() error) (err error) {
+	defer Recover(&err, "")
+	return f()
+}
+
+// panicError creates an error from a panic value v.
+// It must be called while panicking for the stack trace to be captured at the panic site.
+func panicError(v interface{}, op Op) *Error {
+	e := &Error{Op: op, Kind: Server, Code: Unexpected, Stack: panicTrace(), panicked: true}
+	if err, ok := v.(error); ok {
+		e.Msg = "panic"
+		e.Cause = err
+	} else {
+		e.Msg = fmt.Sprintf("panic: %v", v)
+		e.setField(PanicField, v)
+	}
+	return e
+}
+
+// panicTrace returns stack trace of a panicking goroutine starting from the panic site.
+func panicTrace() StackTrace {
+	st := callers()
+	for i := range st {
+		if st[i].name() != "runtime.gopanic" {
+			continue
+		}
+		for i++; i < len(st); i++ {
+			if !strings.HasPrefix(st[i].name(), "runtime.") {
+				break
+			}
+		}
+		return st[i:]
+	}
+	return st
+}
@@ -0,0 +1,114 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_Recover(t *testing.T) {
+	t.Run("no panic", func(t *testing.T) {
+		err := recoverFrom(func() error { return nil })
+		assert.Nil(t, err)
+
+		cause := fmt.Errorf("msg")
+		err = recoverFrom(func() error { return cause })
+		assert.Equal(t, cause, err)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		err := recoverFrom(func() error { panic("boom") })
+		assert.Equal(t, "panic: boom", err.Error())
+		assert.True(t, errors.Is(err, errors.Server))
+		assert.Equal(t, errors.Unexpected, errors.Code(err))
+		assert.Equal(t, []errors.Op{"test.recoverFrom"}, errors.Ops(err))
+		assert.Equal(t, "boom", errors.Fields(err)[errors.PanicField])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := recoverFrom(func() error { panic(fs.ErrClosed) })
+		assert.Equal(t, "panic: file already closed", err.Error())
+		assert.True(t, errors.Is(err, fs.ErrClosed))
+		assert.True(t, errors.Is(err, errors.Server))
+		assert.NotContains(t, errors.Fields(err), errors.PanicField)
+	})
+
+	t.Run("error with code", func(t *testing.T) {
+		cause := errors.E("user not found", errors.Client, errors.NotFound)
+		err := recoverFrom(func() error { panic(cause) })
+		assert.Equal(t, errors.Unexpected, errors.Code(err))
+		assert.True(t, errors.Is(err, errors.Unexpected))
+		assert.False(t, errors.Is(err, errors.NotFound))
+		assert.True(t, errors.Is(err, cause))
+		assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatus(err))
+
+		wrapped := errors.E(errors.Op("op"), "wrapped", err)
+		assert.Equal(t, errors.Unexpected, errors.Code(wrapped))
+		assert.False(t, errors.Is(wrapped, errors.NotFound))
+
+		b, jerr := json.Marshal(wrapped)
+		assert.NoError(t, jerr)
+		res := &errors.Error{}
+		assert.NoError(t, json.Unmarshal(b, res))
+		assert.Equal(t, errors.Unexpected, errors.Code(res))
+		assert.False(t, errors.Is(res, errors.NotFound))
+		assert.True(t, errors.Is(res, errors.Unexpected))
+		assert.True(t, errors.Is(res.Cause, errors.Server))
+	})
+
+	t.Run("classified error", func(t *testing.T) {
+		err := errors.Catch(func() error { panic(fs.ErrNotExist) })
+		assert.Equal(t, errors.Unexpected, errors.Code(err))
+		assert.False(t, errors.Is(err, errors.NotFound))
+		assert.True(t, errors.Is(err, fs.ErrNotExist))
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		err := recoverFrom(func() error {
+			var m map[string]int
+			m["key"] = 1
+			return nil
+		})
+		assert.Contains(t, err.Error(), "assignment to entry in nil map")
+		trace := errors.Trace(err)
+		assert.Contains(t, fmt.Sprintf("%+v", trace[0]), "Test_Recover")
+	})
+
+	t.Run("stack trace", func(t *testing.T) {
+		err := recoverFrom(panicker)
+		trace := errors.Trace(err)
+		assert.NotEmpty(t, trace)
+		assert.Contains(t, fmt.Sprintf("%+v", trace[0]), "panicker")
+		assert.Contains(t, fmt.Sprintf("%+v", trace[0]), "panic_test.go")
+	})
+}
+
+func Test_Catch(t *testing.T) {
+	t.Run("no panic", func(t *testing.T) {
+		assert.Nil(t, errors.Catch(func() error { return nil }))
+		cause := fmt.Errorf("msg")
+		assert.Equal(t, cause, errors.Catch(func() error { return cause }))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		err := errors.Catch(panicker)
+		assert.Equal(t, "panic: 42", err.Error())
+		assert.Equal(t, 42, errors.Fields(err)[errors.PanicField])
+		assert.Empty(t, errors.Ops(err))
+		assert.Contains(t, fmt.Sprintf("%+v", errors.Trace(err)[0]), "panicker")
+	})
+}
+
+func recoverFrom(f func() error) (err error) {
+	defer errors.Recover(&err, "test.recoverFrom")
+	return f()
+}
+
+func panicker() error {
+	panic(42)
+}
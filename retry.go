@@ -0,0 +1,156 @@
+package errors
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Clock provides current time and timers.
+// It could be replaced to control time in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is a Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RetryPolicy configures Retry. Zero values of the fields result in defaults.
+type RetryPolicy struct {
+	// MaxAttempts is a maximum number of attempts. Zero means no limit.
+	MaxAttempts int
+	// MaxElapsed is a maximum time since the first attempt after which
+	// no more attempts are made. Zero means no limit.
+	MaxElapsed time.Duration
+
+	// InitialDelay is a delay before the second attempt, 100ms by default.
+	InitialDelay time.Duration
+	// MaxDelay is a maximum delay between attempts, 30s by default.
+	MaxDelay time.Duration
+	// Multiplier is a factor the delay is multiplied by after each attempt, 2 by default.
+	Multiplier float64
+	// Jitter randomizes a delay. By default, it returns a random delay between zero and d.
+	Jitter func(d time.Duration) time.Duration
+
+	// Retryable checks if an attempt that failed with err could be retried,
+	// IsRetryable by default.
+	Retryable func(err error) bool
+	// Clock is used to measure time and to wait between attempts, system clock by default.
+	Clock Clock
+}
+
+// IsRetryable checks if err is of Transient kind or has Deadlock code.
+func IsRetryable(err error) bool {
+	return IsAnyOf(err, Transient, Deadlock)
+}
+
+// Retry calls fn until it succeeds, fails with an error that is not retryable,
+// the policy limits are exceeded or ctx is done.
+//
+// Delays between attempts grow exponentially and are randomized with full jitter.
+// If an error has a RetryAfter hint, the next attempt is made no earlier than hinted.
+//
+// If all attempts fail, an error wrapping the error of the last attempt is returned.
+// If retries are stopped because ctx is done, the error wraps a List of the error
+// of the last attempt and the cause of ctx, so that errors.Is reports context.Canceled
+// or context.DeadlineExceeded. Errors of all attempts are available with Attempts.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	policy.setDefaults()
+	start := policy.Clock.Now()
+	var attempts List
+	var canceled bool
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		attempts.Add(err)
+		if !policy.Retryable(err) {
+			break
+		}
+		if canceled = ctx.Err() != nil; canceled {
+			break
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			break
+		}
+		delay := policy.delay(attempt, err)
+		if policy.MaxElapsed > 0 && policy.Clock.Now().Add(delay).Sub(start) > policy.MaxElapsed {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-policy.Clock.After(delay):
+		}
+		if canceled = ctx.Err() != nil; canceled {
+			break
+		}
+	}
+	var cause error = attempts[len(attempts)-1]
+	if canceled {
+		cause = List{cause, context.Cause(ctx)}
+	}
+	return &Error{Cause: cause, attempts: attempts}
+}
+
+// Attempts returns errors of all attempts made by Retry, or nil if err
+// is not returned by Retry. Attempts are not part of the fields of the error
+// and are not preserved when the error is encoded to JSON.
+func Attempts(err error) List {
+	var attempts List
+	walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok && e.attempts != nil {
+			attempts = e.attempts
+			return true
+		}
+		return false
+	})
+	return attempts
+}
+
+func (p *RetryPolicy) setDefaults() {
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = 100 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 30 * time.Second
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = 2
+	}
+	if p.Jitter == nil {
+		p.Jitter = fullJitter
+	}
+	if p.Retryable == nil {
+		p.Retryable = IsRetryable
+	}
+	if p.Clock == nil {
+		p.Clock = systemClock{}
+	}
+}
+
+// delay returns a delay after the given failed attempt.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	backoff := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	delay := p.MaxDelay
+	if backoff < float64(p.MaxDelay) {
+		delay = time.Duration(backoff)
+	}
+	delay = p.Jitter(delay)
+	if hint, ok := RetryAfter(err); ok && hint > delay {
+		delay = hint
+	}
+	return delay
+}
+
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
@@ -0,0 +1,203 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+// fakeClock is a clock that advances instantly when waited on.
+type fakeClock struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func Test_Retry(t *testing.T) {
+	noJitter := func(d time.Duration) time.Duration { return d }
+	failing := func(errs ...error) (func(context.Context) error, *int) {
+		calls := 0
+		return func(ctx context.Context) error {
+			calls++
+			if calls > len(errs) {
+				return nil
+			}
+			return errs[calls-1]
+		}, &calls
+	}
+
+	t.Run("success", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing()
+		err := errors.Retry(context.Background(), errors.RetryPolicy{Clock: clock}, fn)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, *calls)
+		assert.Empty(t, clock.delays)
+	})
+
+	t.Run("success after retries", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(errors.E(errors.Transient), errors.E(errors.Deadlock), errors.E(errors.Transient))
+		err := errors.Retry(context.Background(), errors.RetryPolicy{Clock: clock, Jitter: noJitter}, fn)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, *calls)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}, clock.delays)
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		clock := &fakeClock{}
+		last := errors.E("not found", errors.Client, errors.NotFound)
+		fn, calls := failing(errors.E("timeout", errors.Transient), last)
+		err := errors.Retry(context.Background(), errors.RetryPolicy{Clock: clock}, fn)
+		assert.Equal(t, 2, *calls)
+		assert.Equal(t, "not found", err.Error())
+		assert.True(t, errors.Is(err, errors.NotFound))
+		assert.Equal(t, "not found", errors.ClientMsg(err))
+		assert.ErrorIs(t, err, last)
+		attempts := errors.Attempts(err)
+		assert.Len(t, attempts, 2)
+		assert.Equal(t, last, attempts[1])
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(errors.E("e1", errors.Transient), errors.E("e2", errors.Transient), errors.E("e3", errors.Transient), errors.E("e4", errors.Transient))
+		policy := errors.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Second, MaxDelay: 3 * time.Second, Multiplier: 3, Clock: clock, Jitter: noJitter}
+		err := errors.Retry(context.Background(), policy, fn)
+		assert.Equal(t, 3, *calls)
+		assert.Equal(t, "e3", err.Error())
+		assert.True(t, errors.Is(err, errors.Transient))
+		assert.Len(t, errors.Attempts(err), 3)
+		assert.Equal(t, []time.Duration{time.Second, 3 * time.Second}, clock.delays)
+	})
+
+	t.Run("max elapsed", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(errors.E(errors.Transient), errors.E(errors.Transient), errors.E(errors.Transient), errors.E(errors.Transient))
+		policy := errors.RetryPolicy{MaxElapsed: 500 * time.Millisecond, Clock: clock, Jitter: noJitter}
+		err := errors.Retry(context.Background(), policy, fn)
+		assert.Equal(t, 3, *calls)
+		assert.Len(t, errors.Attempts(err), 3)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, clock.delays)
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		clock := &fakeClock{}
+		hinted := errors.E(errors.Transient, errors.F(errors.RetryAfterField, 5*time.Second))
+		fn, _ := failing(hinted, errors.E(errors.Transient))
+		err := errors.Retry(context.Background(), errors.RetryPolicy{Clock: clock, Jitter: noJitter}, fn)
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Duration{5 * time.Second, 200 * time.Millisecond}, clock.delays)
+	})
+
+	t.Run("wrapped retry after", func(t *testing.T) {
+		clock := &fakeClock{}
+		resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": {"7"}}}
+		fn, _ := failing(fmt.Errorf("call: %w", errors.FromResponse(resp)))
+		err := errors.Retry(context.Background(), errors.RetryPolicy{Clock: clock, Jitter: noJitter}, fn)
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Duration{7 * time.Second}, clock.delays)
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, _ := failing(errors.E(errors.Transient), errors.E(errors.Transient), errors.E(errors.Transient))
+		err := errors.Retry(context.Background(), errors.RetryPolicy{Clock: clock, InitialDelay: time.Second}, fn)
+		assert.Nil(t, err)
+		assert.Len(t, clock.delays, 3)
+		for i, d := range clock.delays {
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, time.Second<<i)
+		}
+	})
+
+	t.Run("classifier", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(fmt.Errorf("flaky"), fmt.Errorf("flaky"))
+		policy := errors.RetryPolicy{Clock: clock, Retryable: func(err error) bool { return err.Error() == "flaky" }}
+		assert.Nil(t, errors.Retry(context.Background(), policy, fn))
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := errors.Retry(ctx, errors.RetryPolicy{Clock: &fakeClock{}}, func(ctx context.Context) error {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+			return errors.E("timeout", errors.Transient)
+		})
+		assert.Equal(t, 2, calls)
+		assert.Len(t, errors.Attempts(err), 2)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.True(t, errors.Is(err, errors.Transient))
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := errors.Retry(ctx, errors.RetryPolicy{InitialDelay: time.Hour, Jitter: noJitter}, func(ctx context.Context) error {
+			calls++
+			time.AfterFunc(10*time.Millisecond, cancel)
+			return errors.E("timeout", errors.Transient)
+		})
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "2 errors occurred:\n\t* timeout\n\t* context canceled", err.Error())
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, errors.Attempts(err), 1)
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		cause := errors.E("deadline of the request", errors.Client)
+		ctx, cancel := context.WithDeadlineCause(context.Background(), time.Now().Add(-time.Second), cause)
+		defer cancel()
+		err := errors.Retry(ctx, errors.RetryPolicy{Clock: &fakeClock{}}, func(ctx context.Context) error {
+			return errors.E("timeout", errors.Transient)
+		})
+		assert.ErrorIs(t, err, cause)
+		assert.Equal(t, "timeout", errors.Attempts(err)[0].Error())
+	})
+
+	t.Run("not canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		err := errors.Retry(ctx, errors.RetryPolicy{MaxAttempts: 2, Clock: &fakeClock{}}, func(ctx context.Context) error {
+			return errors.E("timeout", errors.Transient)
+		})
+		assert.False(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, "timeout", err.Error())
+	})
+
+	t.Run("attempts are not fields", func(t *testing.T) {
+		fn, _ := failing(errors.E("e1", errors.Transient), errors.E("e2", errors.Invalid))
+		err := errors.Retry(context.Background(), errors.RetryPolicy{Clock: &fakeClock{}}, fn)
+		assert.Nil(t, errors.Fields(err))
+		assert.NotContains(t, fmt.Sprintf("%+v", err), "e1")
+		wrapped := errors.E(errors.Op("op"), fmt.Errorf("retry: %w", err))
+		assert.Len(t, errors.Attempts(wrapped), 2)
+	})
+
+	t.Run("attempts of other errors", func(t *testing.T) {
+		assert.Nil(t, errors.Attempts(errors.E("not retried")))
+		assert.Nil(t, errors.Attempts(fmt.Errorf("not retried")))
+	})
+}
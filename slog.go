@@ -0,0 +1,194 @@
+package errors
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer.
+// The error is logged as a group with its message, operations, kind, code and fields.
+func (e *Error) LogValue() slog.Value {
+	return logValue(e, false)
+}
+
+// LogValue implements slog.LogValuer.
+// The list is logged as a group of its errors keyed by their index.
+func (l List) LogValue() slog.Value {
+	return logValue(l, false)
+}
+
+func logValue(err error, withStack bool) slog.Value {
+	if list, ok := err.(List); ok {
+		attrs := make([]slog.Attr, 0, len(list))
+		for i := range list {
+			attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: logValue(list[i], withStack)})
+		}
+		return slog.GroupValue(attrs...)
+	}
+	attrs := []slog.Attr{slog.String("msg", err.Error())}
+	if _, ok := err.(*Error); !ok {
+		return slog.GroupValue(attrs...)
+	}
+	if ops := Ops(err); len(ops) > 0 {
+		names := make([]string, len(ops))
+		for i := range ops {
+			names[i] = string(ops[i])
+		}
+		attrs = append(attrs, slog.Any("ops", names))
+	}
+	if kind := Kind(err); kind != 0 {
+		attrs = append(attrs, slog.String("kind", kind.String()))
+	}
+	attrs = append(attrs, slog.String("code", Code(err).String()))
+	if fields := Fields(err); len(fields) > 0 {
+		fieldAttrs := make([]slog.Attr, 0, len(fields))
+		for k, v := range fields {
+			fieldAttrs = append(fieldAttrs, slog.Any(k, v))
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fieldAttrs...)})
+	}
+	if withStack {
+		if frames := Frames(err); len(frames) > 0 {
+			stack := make([]string, len(frames))
+			for i := range frames {
+				stack[i] = frames[i].verbose()
+			}
+			attrs = append(attrs, slog.Any("stack", stack))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// HandlerOptions are options for a Handler.
+type HandlerOptions struct {
+	// Stack enables logging of symbolized stack traces of errors.
+	Stack bool
+
+	// Level chooses record level based on the kind of the logged error.
+	// The level only raises record level, records logged at a higher level keep it.
+	// If Level returns false, record level is left unchanged.
+	// If nil, DefaultLevel is used.
+	//
+	// Records below the minimum level of the next handler are still accepted
+	// if Level could raise them to an enabled level. The highest such level is
+	// found by calling Level for every registered kind and for their union.
+	Level func(kind ErrorKind) (slog.Level, bool)
+}
+
+// Handler is a slog.Handler that expands error-valued attributes into groups
+// and chooses record level based on the kind of the first error in the record.
+type Handler struct {
+	next     slog.Handler
+	opts     HandlerOptions
+	maxLevel slog.Level // highest level Level could choose
+}
+
+// NewHandler creates a Handler that passes expanded records to next.
+// If opts is nil, the default options are used.
+func NewHandler(next slog.Handler, opts *HandlerOptions) *Handler {
+	h := &Handler{next: next}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = DefaultLevel
+	}
+	h.maxLevel = maxLevel(h.opts.Level)
+	return h
+}
+
+// maxLevel returns the highest level chosen by level for registered kinds and their union.
+func maxLevel(level func(kind ErrorKind) (slog.Level, bool)) slog.Level {
+	res := slog.Level(math.MinInt)
+	var all ErrorKind
+	for _, kind := range append(Kinds(), 0) {
+		all |= kind
+		if l, ok := level(kind); ok && l > res {
+			res = l
+		}
+	}
+	if l, ok := level(all); ok && l > res {
+		res = l
+	}
+	return res
+}
+
+// DefaultLevel logs Server errors with slog.LevelError and Client or Transient errors
+// with slog.LevelWarn.
+func DefaultLevel(kind ErrorKind) (slog.Level, bool) {
+	switch {
+	case kind&Server > 0:
+		return slog.LevelError, true
+	case kind&(Client|Transient) > 0:
+		return slog.LevelWarn, true
+	}
+	return 0, false
+}
+
+// Enabled reports whether the next handler handles records at the given level,
+// or at a higher level the record could be raised to by HandlerOptions.Level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.next.Enabled(ctx, level) {
+		return true
+	}
+	return h.maxLevel > level && h.next.Enabled(ctx, h.maxLevel)
+}
+
+// Handle expands error-valued attributes of the record and passes it to the next handler,
+// unless the next handler does not handle records at the chosen level.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(h.expand(a, &err))
+		return true
+	})
+	if err != nil {
+		if level, ok := h.opts.Level(Kind(err)); ok && level > nr.Level {
+			nr.Level = level
+		}
+	}
+	if !h.next.Enabled(ctx, nr.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, nr)
+}
+
+// WithAttrs returns a Handler whose attributes consist of h's attributes followed by attrs.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var err error
+	expanded := make([]slog.Attr, len(attrs))
+	for i := range attrs {
+		expanded[i] = h.expand(attrs[i], &err)
+	}
+	return &Handler{next: h.next.WithAttrs(expanded), opts: h.opts, maxLevel: h.maxLevel}
+}
+
+// WithGroup returns a Handler with the given group appended to h's existing groups.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), opts: h.opts, maxLevel: h.maxLevel}
+}
+
+// expand replaces error values in a with their log values.
+// First error found is stored in first.
+func (h *Handler) expand(a slog.Attr, first *error) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindAny, slog.KindLogValuer:
+		if err, ok := a.Value.Any().(error); ok && err != nil {
+			if *first == nil {
+				*first = err
+			}
+			return slog.Attr{Key: a.Key, Value: logValue(err, h.opts.Stack)}
+		}
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i := range group {
+			attrs[i] = h.expand(group[i], first)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	}
+	return a
+}
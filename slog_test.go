@@ -0,0 +1,163 @@
+package errors_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_LogValue(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		err1 := errors.E(errors.Op("op1"), "msg1", errors.Client, errors.NotFound, errors.F("id", 42))
+		err2 := errors.E(errors.Op("op2"), "msg2", err1)
+		rec := logRecord(t, jsonHandler, err2)
+		assert.Equal(t, map[string]interface{}{
+			"msg":    "msg2: msg1",
+			"ops":    []interface{}{"op2", "op1"},
+			"kind":   "Client",
+			"code":   "NotFound",
+			"fields": map[string]interface{}{"id": float64(42)},
+		}, rec["err"])
+	})
+
+	t.Run("multiple kinds", func(t *testing.T) {
+		err := errors.E("msg", errors.Server, errors.Transient, errors.IO)
+		rec := logRecord(t, jsonHandler, err)
+		assert.Equal(t, map[string]interface{}{
+			"msg":  "msg",
+			"kind": "Server|Transient",
+			"code": "IO",
+		}, rec["err"])
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list := errors.List{fmt.Errorf("msg1"), errors.E("msg2", errors.Invalid)}
+		rec := logRecord(t, jsonHandler, list)
+		assert.Equal(t, map[string]interface{}{
+			"0": map[string]interface{}{"msg": "msg1"},
+			"1": map[string]interface{}{"msg": "msg2", "code": "Invalid"},
+		}, rec["err"])
+	})
+}
+
+func Test_Handler(t *testing.T) {
+	newHandler := func(opts *errors.HandlerOptions) func(w io.Writer) slog.Handler {
+		return func(w io.Writer) slog.Handler {
+			return errors.NewHandler(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}), opts)
+		}
+	}
+
+	t.Run("string error", func(t *testing.T) {
+		rec := logRecord(t, newHandler(nil), fmt.Errorf("msg"))
+		assert.Equal(t, map[string]interface{}{"msg": "msg"}, rec["err"])
+		assert.Equal(t, "INFO", rec["level"])
+	})
+
+	t.Run("level", func(t *testing.T) {
+		cases := []struct {
+			err   error
+			level string
+		}{
+			{errors.E("msg"), "INFO"},
+			{errors.E("msg", errors.Client), "WARN"},
+			{errors.E("msg", errors.Transient), "WARN"},
+			{errors.E("msg", errors.Server), "ERROR"},
+			{errors.E("msg", errors.Server, errors.Transient), "ERROR"},
+		}
+		for _, tc := range cases {
+			rec := logRecord(t, newHandler(nil), tc.err)
+			assert.Equal(t, tc.level, rec["level"])
+		}
+	})
+
+	t.Run("custom level", func(t *testing.T) {
+		level := func(kind errors.ErrorKind) (slog.Level, bool) {
+			return slog.LevelError + 4, kind&errors.Client > 0
+		}
+		rec := logRecord(t, newHandler(&errors.HandlerOptions{Level: level}), errors.E("msg", errors.Client))
+		assert.Equal(t, "ERROR+4", rec["level"])
+		rec = logRecord(t, newHandler(&errors.HandlerOptions{Level: level}), errors.E("msg", errors.Server))
+		assert.Equal(t, "INFO", rec["level"])
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		next := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+		logger := slog.New(errors.NewHandler(next, nil))
+
+		logger.Info("db failed", "err", errors.E("boom", errors.Server))
+		var rec map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+		assert.Equal(t, "ERROR", rec["level"])
+		assert.Equal(t, "db failed", rec["msg"])
+
+		buf.Reset()
+		logger.Info("no error")
+		logger.Info("kindless", "err", errors.E("boom"))
+		assert.Empty(t, buf.String())
+
+		logger.Debug("client", "err", errors.E("boom", errors.Client))
+		assert.Contains(t, buf.String(), `"level":"WARN","msg":"client"`)
+
+		buf.Reset()
+		lowering := func(kind errors.ErrorKind) (slog.Level, bool) { return slog.LevelDebug, true }
+		logger = slog.New(errors.NewHandler(next, &errors.HandlerOptions{Level: lowering}))
+		assert.False(t, logger.Enabled(context.Background(), slog.LevelInfo))
+		logger.Error("not lowered", "err", errors.E("boom", errors.Server))
+		assert.Contains(t, buf.String(), `"level":"ERROR","msg":"not lowered"`)
+	})
+
+	t.Run("error record with client error", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		next := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelError})
+		logger := slog.New(errors.NewHandler(next, nil))
+		logger.Error("request failed", "err", errors.E("bad input", errors.Client))
+		var rec map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+		assert.Equal(t, "ERROR", rec["level"])
+		assert.Equal(t, "request failed", rec["msg"])
+	})
+
+	t.Run("stack", func(t *testing.T) {
+		rec := logRecord(t, newHandler(&errors.HandlerOptions{Stack: true}), findUser(1))
+		stack := rec["err"].(map[string]interface{})["stack"].([]interface{})
+		assert.NotEmpty(t, stack)
+		assert.Contains(t, stack[0], "findUser")
+	})
+
+	t.Run("group and attrs", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		h := newHandler(nil)(buf)
+		logger := slog.New(h).With("base", errors.E("base", errors.Invalid)).WithGroup("g")
+		logger.Info("message", slog.Group("nested", "err", errors.E("msg", errors.Server)))
+		var rec map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+		assert.Equal(t, "ERROR", rec["level"])
+		assert.Equal(t, map[string]interface{}{"msg": "base", "code": "Invalid"}, rec["base"])
+		assert.Equal(t, map[string]interface{}{
+			"nested": map[string]interface{}{
+				"err": map[string]interface{}{"msg": "msg", "kind": "Server", "code": "Unexpected"},
+			},
+		}, rec["g"])
+	})
+}
+
+func logRecord(t *testing.T, newHandler func(w io.Writer) slog.Handler, err error) map[string]interface{} {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	slog.New(newHandler(buf)).Info("message", "err", err)
+	var rec map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	return rec
+}
+
+func jsonHandler(w io.Writer) slog.Handler {
+	return slog.NewJSONHandler(w, nil)
+}
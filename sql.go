@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	stderr "errors"
+	"strings"
+)
+
+// SQLStateError is a database error with an SQLSTATE code,
+// like errors of PostgreSQL drivers.
+type SQLStateError interface {
+	error
+	SQLState() string
+}
+
+// SQLNumberError is a database error with a vendor specific numeric code,
+// like MySQL errors. Drivers that do not provide such a method could be
+// adapted by wrapping their errors.
+type SQLNumberError interface {
+	error
+	SQLErrorNumber() int
+}
+
+func init() {
+	RegisterSentinel(sql.ErrNoRows, 0, NotFound)
+	RegisterSentinel(sql.ErrTxDone, Server, 0)
+	RegisterSentinel(driver.ErrBadConn, Transient, IO)
+	RegisterClassifier(classifySQLState)
+	RegisterClassifier(classifySQLNumber)
+}
+
+// classifySQLState recognizes errors by their SQLSTATE codes.
+func classifySQLState(err error) (ErrorKind, ErrorCode, bool) {
+	var e SQLStateError
+	if !stderr.As(err, &e) {
+		return 0, 0, false
+	}
+	state := e.SQLState()
+	switch {
+	case state == "40001", state == "40P01":
+		// serialization_failure, deadlock_detected
+		return Transient, Deadlock, true
+	case state == "23505":
+		// unique_violation
+		return 0, AlreadyExists, true
+	case strings.HasPrefix(state, "23"):
+		// integrity_constraint_violation, like foreign_key_violation
+		return 0, Invalid, true
+	case strings.HasPrefix(state, "08"):
+		// connection_exception
+		return Transient, IO, true
+	}
+	return 0, 0, false
+}
+
+// classifySQLNumber recognizes errors by their MySQL error numbers.
+func classifySQLNumber(err error) (ErrorKind, ErrorCode, bool) {
+	var e SQLNumberError
+	if !stderr.As(err, &e) {
+		return 0, 0, false
+	}
+	switch e.SQLErrorNumber() {
+	case 1213:
+		// ER_LOCK_DEADLOCK
+		return Transient, Deadlock, true
+	case 1205:
+		// ER_LOCK_WAIT_TIMEOUT
+		return Transient, 0, true
+	case 1062:
+		// ER_DUP_ENTRY
+		return 0, AlreadyExists, true
+	case 1451, 1452:
+		// ER_ROW_IS_REFERENCED_2, ER_NO_REFERENCED_ROW_2
+		return 0, Invalid, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,79 @@
+package errors_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+// pgError is a fake PostgreSQL driver error.
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string    { return "pg error " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+// mysqlError is a fake MySQL driver error.
+type mysqlError struct {
+	number uint16
+}
+
+func (e *mysqlError) Error() string       { return fmt.Sprintf("Error %d", e.number) }
+func (e *mysqlError) SQLErrorNumber() int { return int(e.number) }
+
+func Test_ClassifySQL(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		kind errors.ErrorKind
+		code errors.ErrorCode
+	}{
+		{"no rows", sql.ErrNoRows, 0, errors.NotFound},
+		{"tx done", sql.ErrTxDone, errors.Server, errors.Unexpected},
+		{"bad conn", fmt.Errorf("query: %w", driver.ErrBadConn), errors.Transient, errors.IO},
+		{"serialization failure", &pgError{"40001"}, errors.Transient, errors.Deadlock},
+		{"deadlock detected", &pgError{"40P01"}, errors.Transient, errors.Deadlock},
+		{"unique violation", &pgError{"23505"}, 0, errors.AlreadyExists},
+		{"foreign key violation", &pgError{"23503"}, 0, errors.Invalid},
+		{"not null violation", &pgError{"23502"}, 0, errors.Invalid},
+		{"connection failure", &pgError{"08006"}, errors.Transient, errors.IO},
+		{"syntax error", &pgError{"42601"}, 0, errors.Unexpected},
+		{"wrapped sqlstate", fmt.Errorf("insert: %w", &pgError{"23505"}), 0, errors.AlreadyExists},
+		{"mysql deadlock", &mysqlError{1213}, errors.Transient, errors.Deadlock},
+		{"mysql lock wait timeout", &mysqlError{1205}, errors.Transient, errors.Unexpected},
+		{"mysql duplicate entry", &mysqlError{1062}, 0, errors.AlreadyExists},
+		{"mysql row is referenced", &mysqlError{1451}, 0, errors.Invalid},
+		{"mysql no referenced row", &mysqlError{1452}, 0, errors.Invalid},
+		{"mysql syntax error", &mysqlError{1064}, 0, errors.Unexpected},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.kind, errors.Kind(tc.err))
+			assert.Equal(t, tc.code, errors.Code(tc.err))
+			assert.True(t, errors.Is(tc.err, tc.code))
+
+			wrapped := errors.E(errors.Op("repo.Save"), tc.err)
+			assert.Equal(t, tc.kind, errors.Kind(wrapped))
+			assert.Equal(t, tc.code, errors.Code(wrapped))
+		})
+	}
+
+	t.Run("http status", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatus(fmt.Errorf("commit: %w", sql.ErrTxDone)))
+		assert.Equal(t, http.StatusNotFound, errors.HTTPStatus(sql.ErrNoRows))
+		assert.Equal(t, http.StatusConflict, errors.HTTPStatus(&pgError{"23505"}))
+	})
+
+	t.Run("retryable", func(t *testing.T) {
+		assert.True(t, errors.IsRetryable(&pgError{"40P01"}))
+		assert.True(t, errors.IsRetryable(errors.E(errors.Op("repo.Save"), &mysqlError{1213})))
+		assert.False(t, errors.IsRetryable(&pgError{"23505"}))
+		assert.False(t, errors.IsRetryable(sql.ErrNoRows))
+	})
+}
@@ -141,6 +141,30 @@ func (st StackTrace) formatSlice(s fmt.State, verb rune) {
 	io.WriteString(s, "]")
 }
 
+// Frame is a symbolized StackFrame.
+type Frame struct {
+	Func string `json:"func"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// verbose formats the frame like StackFrame does with %+v.
+func (f Frame) verbose() string {
+	return f.Func + "\n\t" + f.File + ":" + strconv.Itoa(f.Line)
+}
+
+// Frames returns symbolized frames of the stack trace.
+func (st StackTrace) Frames() []Frame {
+	if st == nil {
+		return nil
+	}
+	frames := make([]Frame, len(st))
+	for i, f := range st {
+		frames[i] = Frame{Func: f.name(), File: f.file(), Line: f.line()}
+	}
+	return frames
+}
+
 func callers() StackTrace {
 	const depth = 32
 	const framesToSkip = 3
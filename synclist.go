@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"sync"
+)
+
+// SyncList is a List that is safe for concurrent use.
+// It could be used to accumulate errors from callbacks or goroutines.
+//
+// A zero SyncList is an empty list ready to use.
+type SyncList struct {
+	mut  sync.Mutex
+	list List
+}
+
+// Add adds an error to list.
+func (l *SyncList) Add(e error) {
+	if e == nil {
+		return
+	}
+	l.mut.Lock()
+	l.list.Add(e)
+	l.mut.Unlock()
+}
+
+// Len returns the number of errors in the list.
+func (l *SyncList) Len() int {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	return len(l.list)
+}
+
+// Snapshot returns a copy of the errors accumulated so far.
+func (l *SyncList) Snapshot() List {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	if len(l.list) == 0 {
+		return nil
+	}
+	res := make(List, len(l.list))
+	copy(res, l.list)
+	return res
+}
+
+// ErrOrNil returns nil if the list is empty, otherwise a snapshot of the list.
+func (l *SyncList) ErrOrNil() error {
+	return l.Snapshot().ErrOrNil()
+}
+
+// Drain adds all errors received from ch to the list.
+// It blocks until ch is closed.
+func (l *SyncList) Drain(ch <-chan error) {
+	for err := range ch {
+		l.Add(err)
+	}
+}
@@ -0,0 +1,82 @@
+package errors_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_SyncList(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var list errors.SyncList
+		list.Add(nil)
+		assert.Equal(t, 0, list.Len())
+		assert.Nil(t, list.Snapshot())
+		assert.Nil(t, list.ErrOrNil())
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		err1, err2 := fmt.Errorf("err1"), errors.E("err2")
+		var list errors.SyncList
+		list.Add(err1)
+		snapshot := list.Snapshot()
+		list.Add(err2)
+		assert.Equal(t, errors.List{err1}, snapshot)
+		assert.Equal(t, errors.List{err1, err2}, list.Snapshot())
+		assert.Equal(t, errors.List{err1, err2}, list.ErrOrNil())
+		assert.Equal(t, 2, list.Len())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines, errs = 8, 100
+		var list errors.SyncList
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < errs; i++ {
+					list.Add(errors.E(fmt.Sprintf("err%d-%d", g, i)))
+					_ = list.Len()
+					_ = list.Snapshot()
+				}
+			}(g)
+		}
+		wg.Wait()
+		assert.Equal(t, goroutines*errs, list.Len())
+		assert.Len(t, list.Snapshot(), goroutines*errs)
+	})
+
+	t.Run("drain", func(t *testing.T) {
+		const producers, errs = 4, 50
+		var list errors.SyncList
+		ch := make(chan error)
+		done := make(chan struct{})
+		go func() {
+			list.Drain(ch)
+			close(done)
+		}()
+
+		var wg sync.WaitGroup
+		for p := 0; p < producers; p++ {
+			wg.Add(1)
+			go func(p int) {
+				defer wg.Done()
+				for i := 0; i < errs; i++ {
+					if i%2 == 0 {
+						ch <- nil
+						continue
+					}
+					ch <- fmt.Errorf("err%d-%d", p, i)
+				}
+			}(p)
+		}
+		wg.Wait()
+		close(ch)
+		<-done
+		assert.Equal(t, producers*errs/2, list.Len())
+	})
+}
@@ -0,0 +1,142 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Violation describes a field of a request that failed a validation rule.
+type Violation struct {
+	// Path is a path to the field, like items[3].email.
+	Path string `json:"path"`
+	// Rule is a name of the failed rule, like required or max_length.
+	Rule string `json:"rule"`
+	// Msg is a human readable message.
+	Msg string `json:"msg"`
+	// Params are parameters of the rule, like the maximum length.
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// Error returns human readable representation of a violation prefixed with field path.
+func (v Violation) Error() string {
+	if v.Path == "" {
+		return v.Msg
+	}
+	return v.Path + ": " + v.Msg
+}
+
+// Validator collects field violations into a single error.
+//
+//	v := errors.Validator{}
+//	v.Check(req.Name != "", "name", "required", "name is required")
+//	for i, item := range req.Items {
+//		v.Check(len(item.Email) <= 64, errors.Path("items", i, "email"), "max_length",
+//			"email is too long", errors.F("max", 64))
+//	}
+//	return v.Err()
+//
+// A zero Validator has no violations and is ready to use.
+type Validator struct {
+	list List
+}
+
+// Add adds a violation of the rule by the field at path.
+func (v *Validator) Add(path, rule, msg string, params ...Field) {
+	var p map[string]interface{}
+	if len(params) > 0 {
+		p = make(map[string]interface{}, len(params))
+		for _, f := range params {
+			p[f.Key] = f.Value
+		}
+	}
+	v.list.Add(&Error{
+		Kind:  Client,
+		Code:  Invalid,
+		Cause: Violation{Path: path, Rule: rule, Msg: msg, Params: p},
+	})
+}
+
+// Check adds a violation of the rule by the field at path unless ok is true.
+// It returns ok.
+func (v *Validator) Check(ok bool, path, rule, msg string, params ...Field) bool {
+	if !ok {
+		v.Add(path, rule, msg, params...)
+	}
+	return ok
+}
+
+// Len returns the number of violations.
+func (v *Validator) Len() int {
+	return len(v.list)
+}
+
+// Err returns nil if there are no violations, otherwise an error of Client kind
+// with Invalid code. The cause of the error is a List of violations, each
+// of which is also of Client kind with Invalid code.
+func (v *Validator) Err() error {
+	if len(v.list) == 0 {
+		return nil
+	}
+	list := make(List, len(v.list))
+	copy(list, v.list)
+	return &Error{
+		Kind:  Client,
+		Code:  Invalid,
+		Msg:   "validation failed",
+		Cause: list,
+	}
+}
+
+// Path builds a field path from names and indices:
+//
+//	errors.Path("items", 3, "email") // items[3].email
+func Path(elems ...interface{}) string {
+	sb := &strings.Builder{}
+	for _, elem := range elems {
+		switch elem := elem.(type) {
+		case int:
+			sb.WriteByte('[')
+			sb.WriteString(strconv.Itoa(elem))
+			sb.WriteByte(']')
+		case string:
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(elem)
+		default:
+			panic("bad call to Path: element must be string or int")
+		}
+	}
+	return sb.String()
+}
+
+// Violations returns all field violations found in err's chain,
+// including errors in lists and other multi-errors.
+func Violations(err error) []Violation {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case Violation:
+		return []Violation{e}
+	case multiError:
+		var res []Violation
+		for _, e := range e.Unwrap() {
+			res = append(res, Violations(e)...)
+		}
+		return res
+	}
+	return Violations(Unwrap(err))
+}
+
+// ViolationMessages returns messages of field violations found in err's chain
+// keyed by field paths. It returns nil if there are no violations.
+func ViolationMessages(err error) map[string][]string {
+	var res map[string][]string
+	for _, v := range Violations(err) {
+		if res == nil {
+			res = make(map[string][]string)
+		}
+		res[v.Path] = append(res[v.Path], v.Msg)
+	}
+	return res
+}
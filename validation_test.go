@@ -0,0 +1,105 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/w1ck3dg0ph3r/go-errors"
+)
+
+func Test_Validator(t *testing.T) {
+	newValidator := func() *errors.Validator {
+		v := &errors.Validator{}
+		assert.True(t, v.Check(true, "name", "required", "name is required"))
+		assert.False(t, v.Check(false, "items[3].email", "max_length", "email is too long", errors.F("max", 64)))
+		v.Add("items[3].email", "email", "email is invalid")
+		return v
+	}
+
+	t.Run("no violations", func(t *testing.T) {
+		v := errors.Validator{}
+		v.Check(true, "name", "required", "name is required")
+		assert.Equal(t, 0, v.Len())
+		assert.Nil(t, v.Err())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		v := newValidator()
+		assert.Equal(t, 2, v.Len())
+		err := v.Err()
+		assert.True(t, errors.Is(err, errors.Client))
+		assert.True(t, errors.Is(err, errors.Invalid))
+		assert.Equal(t, "validation failed", errors.ClientMsg(err))
+		assert.Equal(t, "validation failed: 2 errors occurred:\n\t* items[3].email: email is too long\n\t* items[3].email: email is invalid", err.Error())
+
+		var e *errors.Error
+		assert.True(t, errors.As(err, &e))
+		list := e.Cause.(errors.List)
+		assert.Len(t, list, 2)
+		assert.True(t, errors.Has(list, errors.Invalid))
+		assert.True(t, errors.Has(list, errors.Client))
+		assert.False(t, errors.Has(list, errors.NotFound))
+
+		v.Add("name", "required", "name is required")
+		assert.Len(t, errors.Violations(err), 2)
+
+		trace := errors.Trace(errors.E(errors.Op("op"), err))
+		assert.NotEmpty(t, trace)
+		assert.Contains(t, fmt.Sprintf("%+v", trace[0]), "Test_Validator")
+	})
+
+	t.Run("violations", func(t *testing.T) {
+		err := errors.E(errors.Op("createOrder"), newValidator().Err())
+		assert.Equal(t, []errors.Violation{
+			{Path: "items[3].email", Rule: "max_length", Msg: "email is too long", Params: map[string]interface{}{"max": 64}},
+			{Path: "items[3].email", Rule: "email", Msg: "email is invalid"},
+		}, errors.Violations(err))
+		assert.Nil(t, errors.Violations(errors.E("no violations")))
+		assert.Nil(t, errors.Violations(nil))
+
+		var v errors.Violation
+		assert.True(t, errors.As(err, &v))
+		assert.Equal(t, "max_length", v.Rule)
+	})
+
+	t.Run("messages", func(t *testing.T) {
+		v := newValidator()
+		v.Add("name", "required", "name is required")
+		assert.Equal(t, map[string][]string{
+			"items[3].email": {"email is too long", "email is invalid"},
+			"name":           {"name is required"},
+		}, errors.ViolationMessages(v.Err()))
+		assert.Nil(t, errors.ViolationMessages(errors.E(errors.Invalid)))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		err := errors.E(errors.Op("createOrder"), newValidator().Err())
+		b1, jerr := json.Marshal(err)
+		assert.NoError(t, jerr)
+
+		res := &errors.Error{}
+		assert.NoError(t, json.Unmarshal(b1, res))
+		assert.Equal(t, err.Error(), res.Error())
+		assert.True(t, errors.Has(res, errors.Invalid))
+		assert.Equal(t, []errors.Violation{
+			{Path: "items[3].email", Rule: "max_length", Msg: "email is too long", Params: map[string]interface{}{"max": float64(64)}},
+			{Path: "items[3].email", Rule: "email", Msg: "email is invalid"},
+		}, errors.Violations(res))
+
+		b2, jerr := json.Marshal(res)
+		assert.NoError(t, jerr)
+		assert.JSONEq(t, string(b1), string(b2))
+	})
+}
+
+func Test_Path(t *testing.T) {
+	assert.Equal(t, "", errors.Path())
+	assert.Equal(t, "name", errors.Path("name"))
+	assert.Equal(t, "items[3].email", errors.Path("items", 3, "email"))
+	assert.Equal(t, "[0][1].tags[2]", errors.Path(0, 1, "tags", 2))
+	assert.PanicsWithValue(t, "bad call to Path: element must be string or int", func() {
+		errors.Path(1.5)
+	})
+}